@@ -0,0 +1,77 @@
+package kkcity
+
+import "errors"
+
+// API to define the lookups which can be served embedded or remotely.
+type API interface {
+	GetCountries(langIndex int) ([]string, []string, error)
+	GetCountryCities(countryID string, langIndex int) ([]string, []string, []string, error)
+	GetCityWithLatLng(lat, lng float32, langIndex int) (string, string, string, error)
+	GetCitiesWithInput(input string, langIndex int) ([]string, []string, []string, error)
+}
+
+// Embedded to implement API with the package functions, Use must be called first.
+type Embedded struct{}
+
+// GetCountries to get all the countries.
+func (Embedded) GetCountries(langIndex int) ([]string, []string, error) {
+	return GetCountries(langIndex)
+}
+
+// GetCountryCities to get all the cities in one country.
+func (Embedded) GetCountryCities(countryID string, langIndex int) ([]string, []string, []string, error) {
+	return GetCountryCities(countryID, langIndex)
+}
+
+// GetCityWithLatLng to get city information with lat and lng.
+func (Embedded) GetCityWithLatLng(lat, lng float32, langIndex int) (string, string, string, error) {
+	return GetCityWithLatLng(lat, lng, langIndex)
+}
+
+// GetCitiesWithInput to get cities with input.
+func (Embedded) GetCitiesWithInput(input string, langIndex int) ([]string, []string, []string, error) {
+	return GetCitiesWithInput(input, langIndex)
+}
+
+// errorCodes the codes of the errors sent by the servers.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrNoPlace, "no_place"},
+	{ErrLimitation, "limitation"},
+	{ErrLanguageIndex, "language_index"},
+	{ErrCountryID, "country_id"},
+	{ErrCountryExisted, "country_existed"},
+	{ErrCityExisted, "city_existed"},
+	{ErrRequestDenied, "request_denied"},
+	{ErrInvalidRequest, "invalid_request"},
+	{ErrNotFound, "not_found"},
+	{ErrUnknown, "unknown"},
+	{ErrNoTimezone, "no_timezone"},
+	{ErrCursor, "cursor"},
+	{ErrSessionToken, "session_token"},
+	{ErrAutocompleteOptions, "autocomplete_options"},
+}
+
+// ErrorCode to get the code of the error to be sent by a server.
+// Return empty if it is not a kkcity error.
+func ErrorCode(err error) string {
+	for _, one := range errorCodes {
+		if errors.Is(err, one.err) {
+			return one.code
+		}
+	}
+	return ""
+}
+
+// CodeError to get the error of the code received by a client.
+// Return nil if the code is unknown.
+func CodeError(code string) error {
+	for _, one := range errorCodes {
+		if one.code == code {
+			return one.err
+		}
+	}
+	return nil
+}
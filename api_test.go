@@ -0,0 +1,22 @@
+package kkcity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCode(t *testing.T) {
+	for _, one := range errorCodes {
+		code := ErrorCode(one.err)
+		assert.Equal(t, one.code, code, "Code is wrong.")
+		assert.Equal(t, one.err, CodeError(code), "Error is wrong.")
+	}
+
+	denied := statusField{Status: "REQUEST_DENIED", ErrorMessage: "The provided API key is invalid."}.err()
+	assert.Equal(t, "request_denied", ErrorCode(denied), "Status error should have code.")
+
+	assert.Equal(t, "", ErrorCode(errors.New("other")), "Other error should have no code.")
+	assert.Nil(t, CodeError("other"), "Unknown code should have no error.")
+}
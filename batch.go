@@ -0,0 +1,107 @@
+package kkcity
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// cellSize the size in degrees of the cells the points are grouped by.
+var cellSize = 0.01
+
+// SetCellSize to set the size in degrees of the cells used by GetCitiesWithLatLngs, 0.01 (about 1 km) by default.
+// The points in one cell are resolved to the same city.
+func SetCellSize(degrees float64) {
+	if degrees > 0 {
+		cellSize = degrees
+	}
+}
+
+// getCellKey to get the key of the cell which the location is in.
+func getCellKey(lat, lng float64) string {
+	return fmt.Sprintf("%g:%d:%d", cellSize, int64(math.Floor(lat/cellSize)), int64(math.Floor(lng/cellSize)))
+}
+
+// GetCitiesWithLatLngs to get the cities of the locations.
+// The locations are grouped by cells, each cell is resolved once with bounded concurrency under the rate limit.
+// The city of a cell is recorded, so the cell is not requested from Google again.
+// The results are in the order of the points, each carries its own error and timezone.
+// The error returned is fatal, such as ErrLimitation, the remaining cells are not requested
+// and the results of their points carry context.Canceled.
+func GetCitiesWithLatLngs(points []LatLng, langIndex int, policy ...NamePolicy) ([]CityResult, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := resolvePoints(context.Background(), points, func(ctx context.Context, cell string, point LatLng) (string, string, string, error) {
+		return handleCellCity(ctx, cell, point, lang, getNamePolicy(policy))
+	})
+	if zoneErr := fillCityTimezones(results); zoneErr != nil {
+		return nil, zoneErr
+	}
+	return results, err
+}
+
+// resolvePoints to resolve the points grouped by cells with resolveEach, each cell is resolved once with its first point.
+// The result of a cell is copied to all its points, in the order of the points. PlaceID is empty if it failed.
+// Return results, the fatal error or the error of ctx
+func resolvePoints(ctx context.Context, points []LatLng, resolve func(ctx context.Context, cell string, point LatLng) (string, string, string, error)) ([]CityResult, error) {
+	var cells []string
+	firsts := make(map[string]LatLng)
+	cellIndexes := make(map[string][]int)
+	for i, one := range points {
+		cell := getCellKey(one.Lat, one.Lng)
+		if _, ok := firsts[cell]; !ok {
+			cells = append(cells, cell)
+			firsts[cell] = one
+		}
+		cellIndexes[cell] = append(cellIndexes[cell], i)
+	}
+
+	// the results of different cells are emitted at the same time, but their points never overlap
+	results := make([]CityResult, len(points))
+	err := resolveEach(ctx, cells, func(ctx context.Context, cell string) (string, string, string, error) {
+		return resolve(ctx, cell, firsts[cell])
+	}, func(result CityResult) {
+		cell := cells[result.Rank]
+		if result.Err != nil {
+			result.PlaceID = ""
+		}
+
+		for _, index := range cellIndexes[cell] {
+			result.Rank = index
+			results[index] = result
+		}
+	})
+	return results, err
+}
+
+// handleCellCity to get the city of the cell, the recorded one is used if existed.
+// point is used to request the city if the cell is not recorded.
+// Return current placeid, city name, address, error
+func handleCellCity(ctx context.Context, cell string, point LatLng, lang string, policy NamePolicy) (string, string, string, error) {
+	existed, placeid, err := getCellPlaceID(cell)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var name, address string
+	if existed {
+		placeid, name, address, err = handleCityInfo(ctx, placeid, lang, policy)
+	} else {
+		var info placeInfo
+		if info, _, err = requestLocationWithLatLng(ctx, float32(point.Lat), float32(point.Lng), lang, cityResultTypes); err != nil {
+			return "", "", "", err
+		}
+		placeid, name, address, err = handleGeocodedCity(ctx, info, lang, policy)
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if err = setCellPlaceID(cell, placeid); err != nil {
+		return "", "", "", err
+	}
+	return placeid, name, address, nil
+}
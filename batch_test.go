@@ -0,0 +1,76 @@
+package kkcity
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCellKey(t *testing.T) {
+	assert.Equal(t, getCellKey(24.471, 118.081), getCellKey(24.479, 118.089), "Points should be in the same cell.")
+	assert.NotEqual(t, getCellKey(24.471, 118.081), getCellKey(24.481, 118.081), "Points should be in different cells.")
+	assert.NotEqual(t, getCellKey(-0.001, 0.001), getCellKey(0.001, 0.001), "Points across the equator should be in different cells.")
+
+	SetCellSize(0.1)
+	defer SetCellSize(0.01)
+	assert.Equal(t, getCellKey(24.41, 118.01), getCellKey(24.49, 118.09), "Points should be in the same cell.")
+	assert.NotEqual(t, "0.01:2447:11808", getCellKey(24.47, 118.08), "Cell size should be in the key.")
+}
+
+func TestResolvePoints(t *testing.T) {
+	points := []LatLng{
+		{Lat: 24.471, Lng: 118.081},
+		{Lat: 0.5, Lng: 0.5},
+		{Lat: 39.91, Lng: 116.39},
+		{Lat: 24.479, Lng: 118.089},
+		{Lat: 0.501, Lng: 0.501},
+	}
+
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	results, err := resolvePoints(context.Background(), points, func(ctx context.Context, cell string, point LatLng) (string, string, string, error) {
+		mu.Lock()
+		calls[cell]++
+		mu.Unlock()
+
+		switch point.Lat {
+		case 0.5:
+			return "", "", "", ErrNoPlace
+		case 39.91:
+			return "beijing", "Beijing", "", nil
+		}
+		return "xiamen", "Xiamen", "", nil
+	})
+	assert.NoError(t, err, "Error of a cell should not be fatal.")
+
+	assert.Equal(t, 3, len(calls), "Points should be grouped by cells.")
+	for cell, n := range calls {
+		assert.Equal(t, 1, n, cell, " should be resolved once.")
+	}
+
+	assert.Equal(t, len(points), len(results), "Each point should have result.")
+	for i, one := range results {
+		assert.Equal(t, i, one.Rank, "Results should be in the order of the points.")
+	}
+	assert.Equal(t, []string{"xiamen", "", "beijing", "xiamen", ""},
+		[]string{results[0].PlaceID, results[1].PlaceID, results[2].PlaceID, results[3].PlaceID, results[4].PlaceID}, "Place ids are wrong.")
+	assert.Equal(t, ErrNoPlace, results[1].Err, "Points of the failed cell should carry the error.")
+	assert.Equal(t, ErrNoPlace, results[4].Err, "Points of the failed cell should carry the error.")
+	assert.Equal(t, "Xiamen", results[3].Name, "Points of the same cell should share the city.")
+
+	// the fatal error cancels the remaining cells
+	SetConcurrency(1)
+	defer SetConcurrency(4)
+
+	results, err = resolvePoints(context.Background(), points, func(ctx context.Context, cell string, point LatLng) (string, string, string, error) {
+		return "", "", "", ErrLimitation
+	})
+	assert.Equal(t, ErrLimitation, err, "Fatal error should be returned.")
+	assert.Equal(t, ErrLimitation, results[0].Err, "Points of the first cell should carry the fatal error.")
+	assert.Equal(t, ErrLimitation, results[3].Err, "Points of the first cell should carry the fatal error.")
+	for _, index := range []int{1, 2, 4} {
+		assert.Equal(t, context.Canceled, results[index].Err, "Points of the remaining cells should be cancelled.")
+	}
+}
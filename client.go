@@ -0,0 +1,81 @@
+package kkcity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultGoogleBaseURL the base URL of Google APIs.
+const defaultGoogleBaseURL = "https://maps.googleapis.com"
+
+var (
+	// httpClient used to request Google APIs.
+	httpClient = http.DefaultClient
+
+	// googleBaseURL the base URL the Google API paths are appended to.
+	googleBaseURL = defaultGoogleBaseURL
+
+	// requestTimeout the timeout of each Google request, 0 means no timeout.
+	requestTimeout = 10 * time.Second
+)
+
+// SetHTTPClient to set the client used to request Google APIs, such as one with a proxy or a custom transport.
+// The client is shared by all the requests so the connections are reused. nil uses http.DefaultClient.
+func SetHTTPClient(client *http.Client) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpClient = client
+}
+
+// SetRequestTimeout to set the timeout of each Google request, 10 seconds by default, 0 means no timeout.
+// The timeout of the client set by SetHTTPClient still applies.
+func SetRequestTimeout(timeout time.Duration) {
+	requestTimeout = timeout
+}
+
+// SetGoogleBaseURL to set the base URL of Google APIs, such as a proxy or a test server.
+// An empty URL uses https://maps.googleapis.com.
+func SetGoogleBaseURL(baseURL string) {
+	if len(baseURL) == 0 {
+		baseURL = defaultGoogleBaseURL
+	}
+	googleBaseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// getJSON to request the Google API of the path under the rate limit, and decode the JSON response to result.
+// The key is added to the parameters. The request is aborted when ctx is done, even while waiting for its turn.
+func getJSON(ctx context.Context, path string, params url.Values, result interface{}) error {
+	if err := googleLimiter.wait(ctx); err != nil {
+		return err
+	}
+
+	if requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+	}
+
+	params.Set("key", googleKey)
+	req, err := http.NewRequest(http.MethodGet, googleBaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Response status: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
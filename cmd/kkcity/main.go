@@ -0,0 +1,253 @@
+// Command kkcity looks up cities and administrates the recorded data.
+//
+// Usage:
+//
+//	kkcity [flags] reverse <lat> <lng>
+//	kkcity [flags] geocode <address>
+//	kkcity [flags] search <input>
+//	kkcity [flags] countries
+//	kkcity [flags] cities <country>
+//	kkcity [flags] refresh <placeid>
+//	kkcity [flags] purge <placeid>
+//	kkcity [flags] stats
+//	kkcity [flags] export <cities|countries> [file]
+//	kkcity [flags] import <cities|countries> <file>
+//
+// The snapshot files are CSV if the name ends with .csv, otherwise NDJSON.
+// export writes NDJSON to stdout if no file is given.
+//
+// The database, key and languages are set like kkcityd with
+// -dsn/KKCITY_DSN, -key/KKCITY_KEY and -langs/KKCITY_LANGS.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/drkaka/kkcity"
+	"github.com/jackc/pgx"
+)
+
+// errUsage to define the command line is wrong.
+var errUsage = errors.New("Wrong usage.")
+
+// getEnv to get the environment variable or the default value.
+func getEnv(key, def string) string {
+	if value := os.Getenv(key); len(value) > 0 {
+		return value
+	}
+	return def
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: kkcity [flags] <command> [args]
+
+Commands:
+  reverse <lat> <lng>   get the city of a location
+  geocode <address>     get the city of an address
+  search <input>        get the cities matching the input
+  countries             list the countries
+  cities <country>      list the recorded cities of a country
+  refresh <placeid>     request the city from Google again
+  purge <placeid>       delete the recorded city
+  stats                 show the statistics of the recorded data
+  export <table> [file] write the cities or countries to a snapshot, .csv or NDJSON
+  import <table> <file> import the cities or countries from a snapshot
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	dsn := flag.String("dsn", getEnv("KKCITY_DSN", ""), "PostgreSQL DSN")
+	key := flag.String("key", getEnv("KKCITY_KEY", ""), "Google API key")
+	langs := flag.String("langs", getEnv("KKCITY_LANGS", "en"), "comma separated ISO-639-1 languages")
+	lang := flag.String("lang", "", "language code of the output, the first of -langs by default")
+	format := flag.String("format", "table", "output format, table or json")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	out, err := newOutput(os.Stdout, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	connConfig, err := pgx.ParseDSN(*dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Wrong DSN: %v\n", err)
+		os.Exit(1)
+	}
+
+	pool, err := pgx.NewConnPool(pgx.ConnPoolConfig{ConnConfig: connConfig, MaxConnections: 5})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Can't connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	kkcity.Use(strings.Split(*langs, ","), *key, pool)
+
+	langIndex := 0
+	if len(*lang) > 0 {
+		if langIndex, err = kkcity.GetLanguageIndex(*lang); err != nil {
+			fmt.Fprintf(os.Stderr, "Language %s is not in -langs.\n", *lang)
+			os.Exit(2)
+		}
+	}
+
+	if err = run(out, flag.Args(), langIndex); err != nil {
+		if err == errUsage {
+			usage()
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run to run the command.
+func run(out *output, args []string, langIndex int) error {
+	command, args := args[0], args[1:]
+
+	switch {
+	case command == "reverse" && len(args) == 2:
+		lat, err := strconv.ParseFloat(args[0], 32)
+		if err != nil {
+			return err
+		}
+
+		lng, err := strconv.ParseFloat(args[1], 32)
+		if err != nil {
+			return err
+		}
+
+		placeid, name, address, err := kkcity.GetCityWithLatLng(float32(lat), float32(lng), langIndex)
+		if err != nil {
+			return err
+		}
+		return out.cities([]string{placeid}, []string{name}, []string{address})
+	case command == "geocode" && len(args) >= 1:
+		placeid, name, address, err := kkcity.GetCityWithAddress(strings.Join(args, " "), langIndex)
+		if err != nil {
+			return err
+		}
+		return out.cities([]string{placeid}, []string{name}, []string{address})
+	case command == "search" && len(args) >= 1:
+		placeIDs, names, addresses, err := kkcity.GetCitiesWithInput(strings.Join(args, " "), langIndex)
+		if err != nil {
+			return err
+		}
+		return out.cities(placeIDs, names, addresses)
+	case command == "countries" && len(args) == 0:
+		countries, err := kkcity.GetCountriesInfo(langIndex)
+		if err != nil {
+			return err
+		}
+		return out.countries(countries)
+	case command == "cities" && len(args) == 1:
+		placeIDs, names, addresses, err := kkcity.GetCountryCities(strings.ToUpper(args[0]), langIndex)
+		if err != nil {
+			return err
+		}
+		return out.cities(placeIDs, names, addresses)
+	case command == "refresh" && len(args) == 1:
+		placeid, name, address, err := kkcity.RefreshCity(args[0], langIndex)
+		if err != nil {
+			return err
+		}
+		return out.cities([]string{placeid}, []string{name}, []string{address})
+	case command == "purge" && len(args) == 1:
+		if err := kkcity.PurgeCity(args[0]); err != nil {
+			return err
+		}
+		return out.message("Purged " + args[0])
+	case command == "stats" && len(args) == 0:
+		stats, err := kkcity.GetStats()
+		if err != nil {
+			return err
+		}
+		return out.stats(stats)
+	case command == "export" && (len(args) == 1 || len(args) == 2):
+		return exportSnapshot(out, args)
+	case command == "import" && len(args) == 2:
+		return importSnapshot(out, args[0], args[1])
+	}
+	return errUsage
+}
+
+// snapshotFormat to get the snapshot format of the file.
+func snapshotFormat(name string) kkcity.Format {
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		return kkcity.FormatCSV
+	}
+	return kkcity.FormatNDJSON
+}
+
+// exportSnapshot to write the cities or countries to the file or stdout.
+func exportSnapshot(out *output, args []string) error {
+	table := args[0]
+	if table != "cities" && table != "countries" {
+		return errUsage
+	}
+
+	w, format := os.Stdout, kkcity.FormatNDJSON
+	if len(args) == 2 {
+		f, err := os.Create(args[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w, format = f, snapshotFormat(args[1])
+	}
+
+	var err error
+	if table == "cities" {
+		err = kkcity.ExportCities(w, format)
+	} else {
+		err = kkcity.ExportCountries(w, format)
+	}
+	if err != nil || w == os.Stdout {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return out.message("Exported " + table + " to " + args[1])
+}
+
+// importSnapshot to import the cities or countries from the file.
+func importSnapshot(out *output, table, name string) error {
+	if table != "cities" && table != "countries" {
+		return errUsage
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var count int
+	if table == "cities" {
+		count, err = kkcity.ImportCities(f, snapshotFormat(name))
+	} else {
+		count, err = kkcity.ImportCountries(f, snapshotFormat(name))
+	}
+	if err != nil {
+		return err
+	}
+	return out.message(fmt.Sprintf("Imported %d %s", count, table))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/drkaka/kkcity"
+)
+
+// output to write the results as table or JSON.
+type output struct {
+	w      io.Writer
+	asJSON bool
+}
+
+// newOutput to create the output of the format, table or json.
+func newOutput(w io.Writer, format string) (*output, error) {
+	switch format {
+	case "table":
+		return &output{w: w}, nil
+	case "json":
+		return &output{w: w, asJSON: true}, nil
+	}
+	return nil, fmt.Errorf("Unknown format: %s", format)
+}
+
+// city to define the JSON of a city.
+type city struct {
+	PlaceID string `json:"placeid"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+func (o *output) json(value interface{}) error {
+	encoder := json.NewEncoder(o.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// table to write the rows with the header.
+func (o *output) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows...) {
+		for i, one := range row {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, one)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+func (o *output) cities(placeIDs, names, addresses []string) error {
+	if o.asJSON {
+		cities := make([]city, len(placeIDs))
+		for i, one := range placeIDs {
+			cities[i] = city{PlaceID: one, Name: names[i], Address: addresses[i]}
+		}
+		return o.json(cities)
+	}
+
+	rows := make([][]string, len(placeIDs))
+	for i, one := range placeIDs {
+		rows[i] = []string{one, names[i], addresses[i]}
+	}
+	return o.table([]string{"PLACEID", "NAME", "ADDRESS"}, rows)
+}
+
+func (o *output) countries(countries []kkcity.Country) error {
+	if o.asJSON {
+		return o.json(countries)
+	}
+
+	rows := make([][]string, len(countries))
+	for i, one := range countries {
+		rows[i] = []string{one.ID, one.Alpha3, one.Name, one.Continent, one.Currency, one.CallingCode, one.Capital}
+	}
+	return o.table([]string{"ID", "ALPHA3", "NAME", "CONTINENT", "CURRENCY", "CALLING", "CAPITAL"}, rows)
+}
+
+func (o *output) stats(stats kkcity.Stats) error {
+	if o.asJSON {
+		return o.json(stats)
+	}
+
+	rows := [][]string{
+		{"countries", fmt.Sprint(stats.Countries)},
+		{"countries with cities", fmt.Sprint(stats.CountriesWithCities)},
+		{"cities", fmt.Sprint(stats.Cities)},
+		{"regions", fmt.Sprint(stats.Regions)},
+		{"replaced placeids", fmt.Sprint(stats.ReplacedPlaceIDs)},
+	}
+
+	var langs []string
+	for one := range stats.CitiesWithName {
+		langs = append(langs, one)
+	}
+	sort.Strings(langs)
+	for _, one := range langs {
+		rows = append(rows, []string{"cities with name_" + one, fmt.Sprint(stats.CitiesWithName[one])})
+	}
+	return o.table([]string{"STAT", "COUNT"}, rows)
+}
+
+func (o *output) message(msg string) error {
+	if o.asJSON {
+		return o.json(map[string]string{"message": msg})
+	}
+	_, err := fmt.Fprintln(o.w, msg)
+	return err
+}
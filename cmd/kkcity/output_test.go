@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/drkaka/kkcity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+	_, err := newOutput(nil, "xml")
+	assert.Error(t, err, "Format should be wrong.")
+
+	var buf bytes.Buffer
+	out, err := newOutput(&buf, "table")
+	assert.NoError(t, err, "Should be able to create output.")
+
+	err = out.cities([]string{"pid"}, []string{"Xiamen"}, []string{"Xiamen, Fujian, China"})
+	assert.NoError(t, err, "Should be able to write.")
+	assert.Equal(t, "PLACEID  NAME    ADDRESS\npid      Xiamen  Xiamen, Fujian, China\n", buf.String(), "Table is wrong.")
+
+	buf.Reset()
+	out, _ = newOutput(&buf, "json")
+	err = out.stats(kkcity.Stats{Cities: 2, CitiesWithName: map[string]int64{"en": 1}})
+	assert.NoError(t, err, "Should be able to write.")
+	assert.Contains(t, buf.String(), `"cities": 2`, "JSON is wrong.")
+	assert.Contains(t, buf.String(), `"en": 1`, "JSON is wrong.")
+}
+
+func TestRunUsage(t *testing.T) {
+	out, _ := newOutput(&bytes.Buffer{}, "table")
+	assert.Equal(t, errUsage, run(out, []string{"reverse", "1"}, 0), "Missing argument should be wrong usage.")
+	assert.Equal(t, errUsage, run(out, []string{"unknown"}, 0), "Unknown command should be wrong usage.")
+}
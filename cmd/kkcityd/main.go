@@ -0,0 +1,72 @@
+// Command kkcityd serves kkcity as an HTTP JSON service.
+//
+// The options can be set with flags or environment variables:
+//
+//	-addr      KKCITY_ADDR       listen address, default :8080
+//	-grpc-addr KKCITY_GRPC_ADDR  gRPC listen address, gRPC is not served if it is empty
+//	-dsn       KKCITY_DSN        PostgreSQL DSN, such as "host=localhost user=kkcity dbname=kkcity"
+//	-key       KKCITY_KEY        Google API key
+//	-langs     KKCITY_LANGS      comma separated ISO-639-1 languages, default en
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/drkaka/kkcity"
+	"github.com/drkaka/kkcity/kkcitypb"
+	"github.com/jackc/pgx"
+	"google.golang.org/grpc"
+)
+
+// getEnv to get the environment variable or the default value.
+func getEnv(key, def string) string {
+	if value := os.Getenv(key); len(value) > 0 {
+		return value
+	}
+	return def
+}
+
+func main() {
+	addr := flag.String("addr", getEnv("KKCITY_ADDR", ":8080"), "listen address")
+	grpcAddr := flag.String("grpc-addr", getEnv("KKCITY_GRPC_ADDR", ""), "gRPC listen address")
+	dsn := flag.String("dsn", getEnv("KKCITY_DSN", ""), "PostgreSQL DSN")
+	key := flag.String("key", getEnv("KKCITY_KEY", ""), "Google API key")
+	langs := flag.String("langs", getEnv("KKCITY_LANGS", "en"), "comma separated ISO-639-1 languages")
+	flag.Parse()
+
+	connConfig, err := pgx.ParseDSN(*dsn)
+	if err != nil {
+		log.Fatalf("Wrong DSN: %v", err)
+	}
+
+	pool, err := pgx.NewConnPool(pgx.ConnPoolConfig{ConnConfig: connConfig, MaxConnections: 10})
+	if err != nil {
+		log.Fatalf("Can't connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	kkcity.Use(strings.Split(*langs, ","), *key, pool)
+
+	if len(*grpcAddr) > 0 {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatalf("Can't listen on %s: %v", *grpcAddr, err)
+		}
+
+		grpcServer := grpc.NewServer()
+		kkcitypb.RegisterKKCityServer(grpcServer, kkcitypb.NewServer())
+
+		log.Printf("kkcityd gRPC is listening on %s", *grpcAddr)
+		go func() {
+			log.Fatal(grpcServer.Serve(listener))
+		}()
+	}
+
+	log.Printf("kkcityd is listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newServer(pool)))
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/drkaka/kkcity"
+	"github.com/jackc/pgx"
+	"golang.org/x/text/language"
+)
+
+// city to define the JSON of a city.
+// Timezone is the IANA timezone of the city, empty if it is not resolved.
+type city struct {
+	PlaceID  string `json:"placeid"`
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// errorResponse to define the JSON of an error.
+// Code is the kkcity error code, such as no_place, empty if it is not a kkcity error.
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// errBadParam to define a missing or wrong query parameter.
+var errBadParam = errors.New("Bad parameter.")
+
+// server to serve the kkcity APIs.
+type server struct {
+	pool    *pgx.ConnPool
+	matcher language.Matcher
+	mux     *http.ServeMux
+}
+
+// newServer to create the server, must be called after kkcity.Use.
+func newServer(pool *pgx.ConnPool) *server {
+	var tags []language.Tag
+	for _, one := range kkcity.GetLanguages() {
+		tags = append(tags, language.Make(one))
+	}
+
+	s := &server{pool: pool, matcher: language.NewMatcher(tags), mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /readyz", s.handleReady)
+	s.mux.HandleFunc("GET /countries", s.handleCountries)
+	s.mux.HandleFunc("GET /countries/{id}/cities", s.handleCountryCities)
+	s.mux.HandleFunc("GET /cities/reverse", s.handleReverse)
+	s.mux.HandleFunc("POST /cities/reverse/batch", s.handleReverseBatch)
+	s.mux.HandleFunc("GET /cities/geocode", s.handleGeocode)
+	s.mux.HandleFunc("GET /cities/autocomplete", s.handleAutoComplete)
+	s.mux.HandleFunc("GET /cities/autocomplete/stream", s.handleAutoCompleteStream)
+	s.mux.HandleFunc("GET /cities/{placeid}", s.handleCity)
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// getLangIndex to get the language index of the request.
+// The lang query parameter is prior to Accept-Language, the first language is used if neither is set.
+func (s *server) getLangIndex(r *http.Request) (int, error) {
+	if lang := r.URL.Query().Get("lang"); len(lang) > 0 {
+		return kkcity.GetLanguageIndex(lang)
+	}
+
+	if accept := r.Header.Get("Accept-Language"); len(accept) > 0 {
+		tags, _, err := language.ParseAcceptLanguage(accept)
+		if err == nil && len(tags) > 0 {
+			_, index, confidence := s.matcher.Match(tags...)
+			if confidence != language.No {
+				return index, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
+// getStatus to get the HTTP status code of the error.
+func getStatus(err error) int {
+	switch {
+	case errors.Is(err, errBadParam), errors.Is(err, kkcity.ErrLanguageIndex), errors.Is(err, kkcity.ErrCursor), errors.Is(err, kkcity.ErrSessionToken),
+		errors.Is(err, kkcity.ErrAutocompleteOptions),
+		errors.Is(err, kkcity.ErrCountryID), errors.Is(err, kkcity.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, kkcity.ErrNoPlace), errors.Is(err, kkcity.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, kkcity.ErrLimitation):
+		return http.StatusTooManyRequests
+	case errors.Is(err, kkcity.ErrRequestDenied), errors.Is(err, kkcity.ErrUnknown):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// nextCursorHeader the response header of the cursor of the next page.
+const nextCursorHeader = "X-Next-Cursor"
+
+// getListOptions to get the listing options from the query parameters limit, cursor, sort, order and named.
+// sort is id or name, order is asc or desc, named is true to list only the ones having a name.
+func getListOptions(r *http.Request) (kkcity.ListOptions, error) {
+	query := r.URL.Query()
+	opts := kkcity.ListOptions{Cursor: query.Get("cursor")}
+
+	if limit := query.Get("limit"); len(limit) > 0 {
+		var err error
+		if opts.Limit, err = strconv.Atoi(limit); err != nil || opts.Limit < 0 {
+			return opts, errBadParam
+		}
+	}
+
+	switch query.Get("sort") {
+	case "", "id":
+	case "name":
+		opts.Sort = kkcity.SortByName
+	default:
+		return opts, errBadParam
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		opts.Descending = true
+	default:
+		return opts, errBadParam
+	}
+
+	opts.NamedOnly = query.Get("named") == "true"
+	return opts, nil
+}
+
+// getAutocompleteOptions to get the autocomplete options from the query parameters
+// location as lat,lng, radius in meters, countries as comma separated codes, region and strictbounds.
+func getAutocompleteOptions(r *http.Request) (kkcity.AutocompleteOptions, error) {
+	query := r.URL.Query()
+	opts := kkcity.AutocompleteOptions{Region: query.Get("region"), StrictBounds: query.Get("strictbounds") == "true"}
+
+	if location := query.Get("location"); len(location) > 0 {
+		parts := strings.Split(location, ",")
+		if len(parts) != 2 {
+			return opts, errBadParam
+		}
+
+		lat, latErr := strconv.ParseFloat(parts[0], 64)
+		lng, lngErr := strconv.ParseFloat(parts[1], 64)
+		if latErr != nil || lngErr != nil {
+			return opts, errBadParam
+		}
+		opts.Location = &kkcity.LatLng{Lat: lat, Lng: lng}
+	}
+
+	if radius := query.Get("radius"); len(radius) > 0 {
+		var err error
+		if opts.Radius, err = strconv.Atoi(radius); err != nil {
+			return opts, errBadParam
+		}
+	}
+
+	if countries := query.Get("countries"); len(countries) > 0 {
+		opts.Countries = strings.Split(countries, ",")
+	}
+	return opts, nil
+}
+
+// writeJSON to write the value as JSON.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError to write the error with its status code.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, getStatus(err), errorResponse{Error: err.Error(), Code: kkcity.ErrorCode(err)})
+}
+
+// toCities to combine the city slices.
+func toCities(placeIDs, names, addresses []string) []city {
+	cities := make([]city, len(placeIDs))
+	for i, one := range placeIDs {
+		cities[i] = city{PlaceID: one, Name: names[i], Address: addresses[i]}
+	}
+	return cities
+}
+
+// resultCity to define the JSON of a city with its own error.
+type resultCity struct {
+	city
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+// toResultCity to convert the result, the error is empty if the city is resolved.
+func toResultCity(result kkcity.CityResult) resultCity {
+	one := resultCity{city: city{PlaceID: result.PlaceID, Name: result.Name, Address: result.Address, Timezone: result.Timezone}}
+	if result.Err != nil {
+		one.Error, one.Code = result.Err.Error(), kkcity.ErrorCode(result.Err)
+	}
+	return one
+}
+
+// toResultCities to convert the results, fatal is the error returned with them.
+// The cities cancelled by the fatal error carry it instead of context.Canceled, so its code is kept.
+func toResultCities(results []kkcity.CityResult, fatal error) []resultCity {
+	cities := make([]resultCity, len(results))
+	for i, one := range results {
+		if fatal != nil && errors.Is(one.Err, context.Canceled) {
+			one.Err = fatal
+		}
+		cities[i] = toResultCity(one)
+	}
+	return cities
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if _, err := s.pool.Exec("SELECT 1"); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *server) handleCountries(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := getListOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := kkcity.ListCountries(langIndex, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if len(page.NextCursor) > 0 {
+		w.Header().Set(nextCursorHeader, page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, page.Countries)
+}
+
+func (s *server) handleCountryCities(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := getListOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	page, err := kkcity.ListCountryCities(r.PathValue("id"), langIndex, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if len(page.NextCursor) > 0 {
+		w.Header().Set(nextCursorHeader, page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, toCities(page.PlaceIDs, page.Names, page.Addresses))
+}
+
+// reverseCity to define the JSON of the city of a location, Level is the result type matched.
+type reverseCity struct {
+	city
+	Level string `json:"level"`
+}
+
+func (s *server) handleReverse(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	lat, latErr := strconv.ParseFloat(query.Get("lat"), 32)
+	lng, lngErr := strconv.ParseFloat(query.Get("lng"), 32)
+	if latErr != nil || lngErr != nil {
+		writeError(w, errBadParam)
+		return
+	}
+
+	// types are the result types of a city, fallback are the higher administrative levels, both comma separated
+	var opts kkcity.ReverseOptions
+	if types := query.Get("types"); len(types) > 0 {
+		opts.ResultTypes = strings.Split(types, ",")
+	}
+	if fallback := query.Get("fallback"); len(fallback) > 0 {
+		opts.Fallback = strings.Split(fallback, ",")
+	}
+
+	result, err := kkcity.GetCityWithLatLngOptions(float32(lat), float32(lng), langIndex, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, reverseCity{city{PlaceID: result.PlaceID, Name: result.Name, Address: result.Address, Timezone: result.Timezone}, result.Level})
+}
+
+func (s *server) handleGeocode(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	address := strings.TrimSpace(r.URL.Query().Get("address"))
+	if len(address) == 0 {
+		writeError(w, errBadParam)
+		return
+	}
+
+	placeid, name, address, err := kkcity.GetCityWithAddress(address, langIndex)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, city{PlaceID: placeid, Name: name, Address: address})
+}
+
+// getSearchOptions to get the input and the search options from the query parameters.
+func getSearchOptions(r *http.Request) (string, kkcity.SearchOptions, error) {
+	query := r.URL.Query()
+	input := query.Get("input")
+	if len(input) == 0 {
+		return "", kkcity.SearchOptions{}, errBadParam
+	}
+
+	// fast returns the names of the predictions without Place Details requests, enrich records them in background
+	opts := kkcity.SearchOptions{
+		SessionToken:       query.Get("session"),
+		Fast:               query.Get("fast") == "true",
+		EnrichInBackground: query.Get("enrich") == "true",
+	}
+
+	// mode is google by default, local searches only the recorded cities, hybrid searches them first
+	switch query.Get("mode") {
+	case "", "google":
+	case "local":
+		opts.Mode = kkcity.SearchLocal
+	case "hybrid":
+		opts.Mode = kkcity.SearchHybrid
+	default:
+		return "", opts, errBadParam
+	}
+
+	var err error
+	opts.Autocomplete, err = getAutocompleteOptions(r)
+	return input, opts, err
+}
+
+// maxBatchPoints the max count of the points of one batch request.
+const maxBatchPoints = 10000
+
+// handleReverseBatch to get the cities of the points, the body is a JSON array of {"lat":..,"lng":..}.
+// Each point carries its own error, the points not resolved after a fatal error, such as limitation, carry it too.
+// The error is written only if no point is returned.
+func (s *server) handleReverseBatch(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var points []kkcity.LatLng
+	if err := json.NewDecoder(r.Body).Decode(&points); err != nil || len(points) > maxBatchPoints {
+		writeError(w, errBadParam)
+		return
+	}
+
+	results, err := kkcity.GetCitiesWithLatLngs(points, langIndex)
+	if err != nil && len(results) == 0 {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResultCities(results, err))
+}
+
+// handleAutoComplete to search the cities, each city carries its own error,
+// the cities not resolved after a fatal error, such as limitation, carry it too.
+// The error is written only if no city is returned, such as the autocomplete request fails.
+func (s *server) handleAutoComplete(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	input, opts, err := getSearchOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := kkcity.SearchCityResults(r.Context(), input, langIndex, opts)
+	if err != nil && len(results) == 0 {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toResultCities(results, err))
+}
+
+// streamCity to define the JSON of a city in the stream.
+type streamCity struct {
+	Rank int `json:"rank"`
+	resultCity
+}
+
+// handleAutoCompleteStream to write the cities as NDJSON, each line is flushed as soon as the city is resolved.
+func (s *server) handleAutoCompleteStream(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	input, opts, err := getSearchOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := kkcity.StreamCityResults(r.Context(), input, langIndex, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	for one := range results {
+		if err := encoder.Encode(streamCity{Rank: one.Rank, resultCity: toResultCity(one)}); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// handleCity to get a city, the session query parameter ends the autocomplete session the city is picked from.
+func (s *server) handleCity(w http.ResponseWriter, r *http.Request) {
+	langIndex, err := s.getLangIndex(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	placeid, name, address, err := kkcity.GetCityWithSession(r.PathValue("placeid"), r.URL.Query().Get("session"), langIndex)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, city{PlaceID: placeid, Name: name, Address: address})
+}
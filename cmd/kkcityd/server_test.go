@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drkaka/kkcity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatus(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, getStatus(kkcity.ErrNoPlace), "No place should be not found.")
+	assert.Equal(t, http.StatusTooManyRequests, getStatus(kkcity.ErrLimitation), "Limitation should be too many requests.")
+	assert.Equal(t, http.StatusBadRequest, getStatus(kkcity.ErrLanguageIndex), "Language should be bad request.")
+	assert.Equal(t, http.StatusBadRequest, getStatus(fmt.Errorf("wrapped: %w", errBadParam)), "Bad parameter should be bad request.")
+	assert.Equal(t, http.StatusInternalServerError, getStatus(errors.New("other")), "Other error should be internal error.")
+}
+
+func TestBadParam(t *testing.T) {
+	s := newServer(nil)
+
+	for _, url := range []string{"/cities/reverse?lat=abc&lng=1", "/cities/reverse?lat=1", "/cities/geocode?address=%20", "/cities/autocomplete", "/cities/autocomplete/stream",
+		"/countries?limit=-1", "/countries?sort=size", "/countries/CN/cities?order=up",
+		"/cities/autocomplete?input=bao&mode=nearby", "/cities/autocomplete?input=bao&location=1",
+		"/cities/autocomplete?input=bao&radius=far"} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, url, " should be bad request.")
+		assert.Contains(t, w.Body.String(), errBadParam.Error(), "Error message is wrong.")
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/countries?lang=xx", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Language should be bad request.")
+	assert.Contains(t, w.Body.String(), `"code":"language_index"`, "Error code is wrong.")
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/cities/placeid1?session=a%26b", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Session token should be bad request.")
+	assert.Contains(t, w.Body.String(), `"code":"session_token"`, "Error code is wrong.")
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("POST", "/cities/reverse/batch", strings.NewReader(`{"lat":1}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Batch body should be bad request.")
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Health should be ok.")
+}
+
+func TestToResultCities(t *testing.T) {
+	cities := toResultCities([]kkcity.CityResult{
+		{PlaceID: "placeid1", Name: "Xiamen", Address: "Xiamen, Fujian, China", Timezone: "Asia/Shanghai"},
+		{Rank: 1, PlaceID: "placeid2", Err: kkcity.ErrLimitation},
+	}, nil)
+
+	assert.Equal(t, resultCity{city: city{PlaceID: "placeid1", Name: "Xiamen", Address: "Xiamen, Fujian, China", Timezone: "Asia/Shanghai"}}, cities[0], "City is wrong.")
+	assert.Equal(t, "placeid2", cities[1].PlaceID, "Place id is wrong.")
+	assert.Equal(t, kkcity.ErrLimitation.Error(), cities[1].Error, "Error is wrong.")
+	assert.Equal(t, kkcity.ErrorCode(kkcity.ErrLimitation), cities[1].Code, "Error code is wrong.")
+}
+
+func TestToResultCitiesWithFatal(t *testing.T) {
+	cities := toResultCities([]kkcity.CityResult{
+		{PlaceID: "placeid1", Name: "Xiamen"},
+		{Rank: 1, Err: kkcity.ErrLimitation},
+		{Rank: 2, Err: context.Canceled},
+	}, kkcity.ErrLimitation)
+
+	assert.Empty(t, cities[0].Error, "Resolved city should have no error.")
+	assert.Equal(t, kkcity.ErrorCode(kkcity.ErrLimitation), cities[1].Code, "Error code is wrong.")
+	assert.Equal(t, kkcity.ErrorCode(kkcity.ErrLimitation), cities[2].Code, "Cancelled city should carry the fatal error.")
+}
@@ -0,0 +1,256 @@
+package kkcity
+
+// countryData the ISO 3166 countries used to seed country_info.
+// Name is the English name, Numeric is empty for user-assigned codes such as XK.
+var countryData = []Country{
+	{ID: "AD", Alpha3: "AND", Numeric: "020", Name: "Andorra", Continent: "Europe", Currency: "EUR", CallingCode: "+376", Capital: "Andorra la Vella"},
+	{ID: "AE", Alpha3: "ARE", Numeric: "784", Name: "United Arab Emirates", Continent: "Asia", Currency: "AED", CallingCode: "+971", Capital: "Abu Dhabi"},
+	{ID: "AF", Alpha3: "AFG", Numeric: "004", Name: "Afghanistan", Continent: "Asia", Currency: "AFN", CallingCode: "+93", Capital: "Kabul"},
+	{ID: "AG", Alpha3: "ATG", Numeric: "028", Name: "Antigua and Barbuda", Continent: "North America", Currency: "XCD", CallingCode: "+1268", Capital: "St. John's"},
+	{ID: "AI", Alpha3: "AIA", Numeric: "660", Name: "Anguilla", Continent: "North America", Currency: "XCD", CallingCode: "+1264", Capital: "The Valley"},
+	{ID: "AL", Alpha3: "ALB", Numeric: "008", Name: "Albania", Continent: "Europe", Currency: "ALL", CallingCode: "+355", Capital: "Tirana"},
+	{ID: "AM", Alpha3: "ARM", Numeric: "051", Name: "Armenia", Continent: "Asia", Currency: "AMD", CallingCode: "+374", Capital: "Yerevan"},
+	{ID: "AO", Alpha3: "AGO", Numeric: "024", Name: "Angola", Continent: "Africa", Currency: "AOA", CallingCode: "+244", Capital: "Luanda"},
+	{ID: "AQ", Alpha3: "ATA", Numeric: "010", Name: "Antarctica", Continent: "Antarctica", Currency: "", CallingCode: "+672", Capital: ""},
+	{ID: "AR", Alpha3: "ARG", Numeric: "032", Name: "Argentina", Continent: "South America", Currency: "ARS", CallingCode: "+54", Capital: "Buenos Aires"},
+	{ID: "AS", Alpha3: "ASM", Numeric: "016", Name: "American Samoa", Continent: "Oceania", Currency: "USD", CallingCode: "+1684", Capital: "Pago Pago"},
+	{ID: "AT", Alpha3: "AUT", Numeric: "040", Name: "Austria", Continent: "Europe", Currency: "EUR", CallingCode: "+43", Capital: "Vienna"},
+	{ID: "AU", Alpha3: "AUS", Numeric: "036", Name: "Australia", Continent: "Oceania", Currency: "AUD", CallingCode: "+61", Capital: "Canberra"},
+	{ID: "AW", Alpha3: "ABW", Numeric: "533", Name: "Aruba", Continent: "North America", Currency: "AWG", CallingCode: "+297,+5998", Capital: "Oranjestad"},
+	{ID: "AX", Alpha3: "ALA", Numeric: "248", Name: "Aland Islands", Continent: "Europe", Currency: "EUR", CallingCode: "+35818", Capital: "Mariehamn"},
+	{ID: "AZ", Alpha3: "AZE", Numeric: "031", Name: "Azerbaijan", Continent: "Asia", Currency: "AZN", CallingCode: "+994", Capital: "Baku"},
+	{ID: "BA", Alpha3: "BIH", Numeric: "070", Name: "Bosnia and Herzegovina", Continent: "Europe", Currency: "BAM", CallingCode: "+387", Capital: "Sarajevo"},
+	{ID: "BB", Alpha3: "BRB", Numeric: "052", Name: "Barbados", Continent: "North America", Currency: "BBD", CallingCode: "+1246", Capital: "Bridgetown"},
+	{ID: "BD", Alpha3: "BGD", Numeric: "050", Name: "Bangladesh", Continent: "Asia", Currency: "BDT", CallingCode: "+880", Capital: "Dhaka"},
+	{ID: "BE", Alpha3: "BEL", Numeric: "056", Name: "Belgium", Continent: "Europe", Currency: "EUR", CallingCode: "+32", Capital: "Brussels"},
+	{ID: "BF", Alpha3: "BFA", Numeric: "854", Name: "Burkina Faso", Continent: "Africa", Currency: "XOF", CallingCode: "+226", Capital: "Ouagadougou"},
+	{ID: "BG", Alpha3: "BGR", Numeric: "100", Name: "Bulgaria", Continent: "Europe", Currency: "BGN", CallingCode: "+359", Capital: "Sofia"},
+	{ID: "BH", Alpha3: "BHR", Numeric: "048", Name: "Bahrain", Continent: "Asia", Currency: "BHD", CallingCode: "+973", Capital: "Manama"},
+	{ID: "BI", Alpha3: "BDI", Numeric: "108", Name: "Burundi", Continent: "Africa", Currency: "BIF", CallingCode: "+257", Capital: "Bujumbura"},
+	{ID: "BJ", Alpha3: "BEN", Numeric: "204", Name: "Benin", Continent: "Africa", Currency: "XOF", CallingCode: "+229", Capital: "Porto-Novo"},
+	{ID: "BL", Alpha3: "BLM", Numeric: "652", Name: "Saint Barthelemy", Continent: "North America", Currency: "EUR", CallingCode: "+590", Capital: "Gustavia"},
+	{ID: "BM", Alpha3: "BMU", Numeric: "060", Name: "Bermuda", Continent: "North America", Currency: "BMD", CallingCode: "+1441", Capital: "Hamilton"},
+	{ID: "BN", Alpha3: "BRN", Numeric: "096", Name: "Brunei Darussalam", Continent: "Asia", Currency: "BND", CallingCode: "+673", Capital: "Bandar Seri Begawan"},
+	{ID: "BO", Alpha3: "BOL", Numeric: "068", Name: "Bolivia", Continent: "South America", Currency: "BOB", CallingCode: "+591", Capital: "Sucre"},
+	{ID: "BQ", Alpha3: "BES", Numeric: "535", Name: "Bonaire, Sint Eustatius and Saba", Continent: "North America", Currency: "USD", CallingCode: "+5993,+5994", Capital: ""},
+	{ID: "BR", Alpha3: "BRA", Numeric: "076", Name: "Brazil", Continent: "South America", Currency: "BRL", CallingCode: "+55", Capital: "Brasilia"},
+	{ID: "BS", Alpha3: "BHS", Numeric: "044", Name: "Bahamas", Continent: "North America", Currency: "BSD", CallingCode: "+1242", Capital: "Nassau"},
+	{ID: "BT", Alpha3: "BTN", Numeric: "064", Name: "Bhutan", Continent: "Asia", Currency: "BTN", CallingCode: "+975", Capital: "Thimphu"},
+	{ID: "BV", Alpha3: "BVT", Numeric: "074", Name: "Bouvet Island", Continent: "Antarctica", Currency: "NOK", CallingCode: "+47", Capital: ""},
+	{ID: "BW", Alpha3: "BWA", Numeric: "072", Name: "Botswana", Continent: "Africa", Currency: "BWP", CallingCode: "+267", Capital: "Gaborone"},
+	{ID: "BY", Alpha3: "BLR", Numeric: "112", Name: "Belarus", Continent: "Europe", Currency: "BYN", CallingCode: "+375", Capital: "Minsk"},
+	{ID: "BZ", Alpha3: "BLZ", Numeric: "084", Name: "Belize", Continent: "North America", Currency: "BZD", CallingCode: "+501", Capital: "Belmopan"},
+	{ID: "CA", Alpha3: "CAN", Numeric: "124", Name: "Canada", Continent: "North America", Currency: "CAD", CallingCode: "+1", Capital: "Ottawa"},
+	{ID: "CC", Alpha3: "CCK", Numeric: "166", Name: "Cocos (Keeling) Islands", Continent: "Asia", Currency: "AUD", CallingCode: "+672,+6189162", Capital: "West Island"},
+	{ID: "CD", Alpha3: "COD", Numeric: "180", Name: "Democratic Republic of the Congo", Continent: "Africa", Currency: "CDF", CallingCode: "+243", Capital: "Kinshasa"},
+	{ID: "CF", Alpha3: "CAF", Numeric: "140", Name: "Central African Republic", Continent: "Africa", Currency: "XAF", CallingCode: "+236", Capital: "Bangui"},
+	{ID: "CG", Alpha3: "COG", Numeric: "178", Name: "Congo", Continent: "Africa", Currency: "XAF", CallingCode: "+242", Capital: "Brazzaville"},
+	{ID: "CH", Alpha3: "CHE", Numeric: "756", Name: "Switzerland", Continent: "Europe", Currency: "CHF", CallingCode: "+41", Capital: "Bern"},
+	{ID: "CI", Alpha3: "CIV", Numeric: "384", Name: "Cote d'Ivoire", Continent: "Africa", Currency: "XOF", CallingCode: "+225", Capital: "Yamoussoukro"},
+	{ID: "CK", Alpha3: "COK", Numeric: "184", Name: "Cook Islands", Continent: "Oceania", Currency: "NZD", CallingCode: "+682", Capital: "Avarua"},
+	{ID: "CL", Alpha3: "CHL", Numeric: "152", Name: "Chile", Continent: "South America", Currency: "CLP", CallingCode: "+56", Capital: "Santiago"},
+	{ID: "CM", Alpha3: "CMR", Numeric: "120", Name: "Cameroon", Continent: "Africa", Currency: "XAF", CallingCode: "+237", Capital: "Yaounde"},
+	{ID: "CN", Alpha3: "CHN", Numeric: "156", Name: "China", Continent: "Asia", Currency: "CNY", CallingCode: "+86", Capital: "Beijing"},
+	{ID: "CO", Alpha3: "COL", Numeric: "170", Name: "Colombia", Continent: "South America", Currency: "COP", CallingCode: "+57", Capital: "Bogota"},
+	{ID: "CR", Alpha3: "CRI", Numeric: "188", Name: "Costa Rica", Continent: "North America", Currency: "CRC", CallingCode: "+506", Capital: "San Jose"},
+	{ID: "CU", Alpha3: "CUB", Numeric: "192", Name: "Cuba", Continent: "North America", Currency: "CUC", CallingCode: "+53", Capital: "Havana"},
+	{ID: "CV", Alpha3: "CPV", Numeric: "132", Name: "Cabo Verde", Continent: "Africa", Currency: "CVE", CallingCode: "+238", Capital: "Praia"},
+	{ID: "CW", Alpha3: "CUW", Numeric: "531", Name: "Curacao", Continent: "Oceania", Currency: "ANG", CallingCode: "+5999", Capital: "Willemstad Curacao"},
+	{ID: "CX", Alpha3: "CXR", Numeric: "162", Name: "Christmas Island", Continent: "Asia", Currency: "AUD", CallingCode: "+6189164", Capital: "Flying Fish Cove"},
+	{ID: "CY", Alpha3: "CYP", Numeric: "196", Name: "Cyprus", Continent: "Asia", Currency: "EUR", CallingCode: "+357", Capital: "Nicosia"},
+	{ID: "CZ", Alpha3: "CZE", Numeric: "203", Name: "Czechia", Continent: "Europe", Currency: "CZK", CallingCode: "+420", Capital: "Prague"},
+	{ID: "DE", Alpha3: "DEU", Numeric: "276", Name: "Germany", Continent: "Europe", Currency: "EUR", CallingCode: "+49", Capital: "Berlin"},
+	{ID: "DJ", Alpha3: "DJI", Numeric: "262", Name: "Djibouti", Continent: "Africa", Currency: "DJF", CallingCode: "+253", Capital: "Djibouti"},
+	{ID: "DK", Alpha3: "DNK", Numeric: "208", Name: "Denmark", Continent: "Europe", Currency: "DKK", CallingCode: "+45", Capital: "Copenhagen"},
+	{ID: "DM", Alpha3: "DMA", Numeric: "212", Name: "Dominica", Continent: "North America", Currency: "XCD", CallingCode: "+1767", Capital: "Roseau"},
+	{ID: "DO", Alpha3: "DOM", Numeric: "214", Name: "Dominican Republic", Continent: "North America", Currency: "DOP", CallingCode: "+1809,+1829,+1849", Capital: "Santo Domingo"},
+	{ID: "DZ", Alpha3: "DZA", Numeric: "012", Name: "Algeria", Continent: "Africa", Currency: "DZD", CallingCode: "+213", Capital: "Algiers"},
+	{ID: "EC", Alpha3: "ECU", Numeric: "218", Name: "Ecuador", Continent: "South America", Currency: "USD", CallingCode: "+593", Capital: "Quito"},
+	{ID: "EE", Alpha3: "EST", Numeric: "233", Name: "Estonia", Continent: "Europe", Currency: "EUR", CallingCode: "+372", Capital: "Tallinn"},
+	{ID: "EG", Alpha3: "EGY", Numeric: "818", Name: "Egypt", Continent: "Africa", Currency: "EGP", CallingCode: "+20", Capital: "Cairo"},
+	{ID: "EH", Alpha3: "ESH", Numeric: "732", Name: "Western Sahara", Continent: "Africa", Currency: "MAD", CallingCode: "+212", Capital: "El-Aaiun"},
+	{ID: "ER", Alpha3: "ERI", Numeric: "232", Name: "Eritrea", Continent: "Africa", Currency: "ERN", CallingCode: "+291", Capital: "Asmara"},
+	{ID: "ES", Alpha3: "ESP", Numeric: "724", Name: "Spain", Continent: "Europe", Currency: "EUR", CallingCode: "+34", Capital: "Madrid"},
+	{ID: "ET", Alpha3: "ETH", Numeric: "231", Name: "Ethiopia", Continent: "Africa", Currency: "ETB", CallingCode: "+251", Capital: "Addis Ababa"},
+	{ID: "FI", Alpha3: "FIN", Numeric: "246", Name: "Finland", Continent: "Europe", Currency: "EUR", CallingCode: "+358", Capital: "Helsinki"},
+	{ID: "FJ", Alpha3: "FJI", Numeric: "242", Name: "Fiji", Continent: "Oceania", Currency: "FJD", CallingCode: "+679", Capital: "Suva"},
+	{ID: "FK", Alpha3: "FLK", Numeric: "238", Name: "Falkland Islands (Malvinas)", Continent: "South America", Currency: "FKP", CallingCode: "+500", Capital: "Stanley"},
+	{ID: "FM", Alpha3: "FSM", Numeric: "583", Name: "Micronesia (Federated States of)", Continent: "Oceania", Currency: "USD", CallingCode: "+691", Capital: "Palikir"},
+	{ID: "FO", Alpha3: "FRO", Numeric: "234", Name: "Faroe Islands", Continent: "Europe", Currency: "DKK", CallingCode: "+298", Capital: "Torshavn"},
+	{ID: "FR", Alpha3: "FRA", Numeric: "250", Name: "France", Continent: "Europe", Currency: "EUR", CallingCode: "+33", Capital: "Paris"},
+	{ID: "GA", Alpha3: "GAB", Numeric: "266", Name: "Gabon", Continent: "Africa", Currency: "XAF", CallingCode: "+241", Capital: "Libreville"},
+	{ID: "GB", Alpha3: "GBR", Numeric: "826", Name: "United Kingdom", Continent: "Europe", Currency: "GBP", CallingCode: "+44", Capital: "London"},
+	{ID: "GD", Alpha3: "GRD", Numeric: "308", Name: "Grenada", Continent: "North America", Currency: "XCD", CallingCode: "+1473", Capital: "St. George's"},
+	{ID: "GE", Alpha3: "GEO", Numeric: "268", Name: "Georgia", Continent: "Asia", Currency: "GEL", CallingCode: "+995", Capital: "Tbilisi"},
+	{ID: "GF", Alpha3: "GUF", Numeric: "254", Name: "French Guiana", Continent: "South America", Currency: "EUR", CallingCode: "+594", Capital: "Cayenne"},
+	{ID: "GG", Alpha3: "GGY", Numeric: "831", Name: "Guernsey", Continent: "Europe", Currency: "GBP", CallingCode: "+441481", Capital: "St Peter Port"},
+	{ID: "GH", Alpha3: "GHA", Numeric: "288", Name: "Ghana", Continent: "Africa", Currency: "GHS", CallingCode: "+233", Capital: "Accra"},
+	{ID: "GI", Alpha3: "GIB", Numeric: "292", Name: "Gibraltar", Continent: "Europe", Currency: "GIP", CallingCode: "+350", Capital: "Gibraltar"},
+	{ID: "GL", Alpha3: "GRL", Numeric: "304", Name: "Greenland", Continent: "North America", Currency: "DKK", CallingCode: "+299", Capital: "Nuuk"},
+	{ID: "GM", Alpha3: "GMB", Numeric: "270", Name: "Gambia", Continent: "Africa", Currency: "GMD", CallingCode: "+220", Capital: "Banjul"},
+	{ID: "GN", Alpha3: "GIN", Numeric: "324", Name: "Guinea", Continent: "Africa", Currency: "GNF", CallingCode: "+224", Capital: "Conakry"},
+	{ID: "GP", Alpha3: "GLP", Numeric: "312", Name: "Guadeloupe", Continent: "North America", Currency: "EUR", CallingCode: "+590", Capital: "Basse-Terre Guadeloupe"},
+	{ID: "GQ", Alpha3: "GNQ", Numeric: "226", Name: "Equatorial Guinea", Continent: "Africa", Currency: "XAF", CallingCode: "+240", Capital: "Malabo"},
+	{ID: "GR", Alpha3: "GRC", Numeric: "300", Name: "Greece", Continent: "Europe", Currency: "EUR", CallingCode: "+30", Capital: "Athens"},
+	{ID: "GS", Alpha3: "SGS", Numeric: "239", Name: "South Georgia and The South Sandwich Islands", Continent: "Antarctica", Currency: "GBP", CallingCode: "+500", Capital: "Grytviken"},
+	{ID: "GT", Alpha3: "GTM", Numeric: "320", Name: "Guatemala", Continent: "North America", Currency: "GTQ", CallingCode: "+502", Capital: "Guatemala City"},
+	{ID: "GU", Alpha3: "GUM", Numeric: "316", Name: "Guam", Continent: "Oceania", Currency: "USD", CallingCode: "+1671", Capital: "Hagatna"},
+	{ID: "GW", Alpha3: "GNB", Numeric: "624", Name: "Guinea-Bissau", Continent: "Africa", Currency: "XOF", CallingCode: "+245", Capital: "Bissau"},
+	{ID: "GY", Alpha3: "GUY", Numeric: "328", Name: "Guyana", Continent: "South America", Currency: "GYD", CallingCode: "+592", Capital: "Georgetown Guyana"},
+	{ID: "HK", Alpha3: "HKG", Numeric: "344", Name: "Hong Kong", Continent: "Asia", Currency: "HKD", CallingCode: "+852", Capital: "Hong Kong"},
+	{ID: "HM", Alpha3: "HMD", Numeric: "334", Name: "Heard Island and McDonald Islands", Continent: "Antarctica", Currency: "AUD", CallingCode: "+61", Capital: ""},
+	{ID: "HN", Alpha3: "HND", Numeric: "340", Name: "Honduras", Continent: "North America", Currency: "HNL", CallingCode: "+504", Capital: "Tegucigalpa"},
+	{ID: "HR", Alpha3: "HRV", Numeric: "191", Name: "Croatia", Continent: "Europe", Currency: "EUR", CallingCode: "+385", Capital: "Zagreb"},
+	{ID: "HT", Alpha3: "HTI", Numeric: "332", Name: "Haiti", Continent: "North America", Currency: "HTG", CallingCode: "+509", Capital: "Port-au-Prince"},
+	{ID: "HU", Alpha3: "HUN", Numeric: "348", Name: "Hungary", Continent: "Europe", Currency: "HUF", CallingCode: "+36", Capital: "Budapest"},
+	{ID: "ID", Alpha3: "IDN", Numeric: "360", Name: "Indonesia", Continent: "Asia", Currency: "IDR", CallingCode: "+62", Capital: "Jakarta"},
+	{ID: "IE", Alpha3: "IRL", Numeric: "372", Name: "Ireland", Continent: "Europe", Currency: "EUR", CallingCode: "+353", Capital: "Dublin"},
+	{ID: "IL", Alpha3: "ISR", Numeric: "376", Name: "Israel", Continent: "Asia", Currency: "ILS", CallingCode: "+972", Capital: "Jerusalem"},
+	{ID: "IM", Alpha3: "IMN", Numeric: "833", Name: "Isle of Man", Continent: "Europe", Currency: "GBP", CallingCode: "+441624", Capital: "Douglas"},
+	{ID: "IN", Alpha3: "IND", Numeric: "356", Name: "India", Continent: "Asia", Currency: "INR", CallingCode: "+91", Capital: "New Delhi"},
+	{ID: "IO", Alpha3: "IOT", Numeric: "086", Name: "British Indian Ocean Territory", Continent: "Asia", Currency: "USD", CallingCode: "+246", Capital: "Diego Garcia"},
+	{ID: "IQ", Alpha3: "IRQ", Numeric: "368", Name: "Iraq", Continent: "Asia", Currency: "IQD", CallingCode: "+964", Capital: "Baghdad"},
+	{ID: "IR", Alpha3: "IRN", Numeric: "364", Name: "Iran (Islamic Republic of)", Continent: "Asia", Currency: "IRR", CallingCode: "+98", Capital: "Tehran"},
+	{ID: "IS", Alpha3: "ISL", Numeric: "352", Name: "Iceland", Continent: "Europe", Currency: "ISK", CallingCode: "+354", Capital: "Reykjavik"},
+	{ID: "IT", Alpha3: "ITA", Numeric: "380", Name: "Italy", Continent: "Europe", Currency: "EUR", CallingCode: "+39", Capital: "Rome"},
+	{ID: "JE", Alpha3: "JEY", Numeric: "832", Name: "Jersey", Continent: "Europe", Currency: "GBP", CallingCode: "+441534", Capital: "Saint Helier"},
+	{ID: "JM", Alpha3: "JAM", Numeric: "388", Name: "Jamaica", Continent: "North America", Currency: "JMD", CallingCode: "+1876,+1658", Capital: "Kingston"},
+	{ID: "JO", Alpha3: "JOR", Numeric: "400", Name: "Jordan", Continent: "Asia", Currency: "JOD", CallingCode: "+962", Capital: "Amman"},
+	{ID: "JP", Alpha3: "JPN", Numeric: "392", Name: "Japan", Continent: "Asia", Currency: "JPY", CallingCode: "+81", Capital: "Tokyo"},
+	{ID: "KE", Alpha3: "KEN", Numeric: "404", Name: "Kenya", Continent: "Africa", Currency: "KES", CallingCode: "+254", Capital: "Nairobi"},
+	{ID: "KG", Alpha3: "KGZ", Numeric: "417", Name: "Kyrgyzstan", Continent: "Asia", Currency: "KGS", CallingCode: "+996", Capital: "Bishkek"},
+	{ID: "KH", Alpha3: "KHM", Numeric: "116", Name: "Cambodia", Continent: "Asia", Currency: "KHR", CallingCode: "+855", Capital: "Phnom Penh"},
+	{ID: "KI", Alpha3: "KIR", Numeric: "296", Name: "Kiribati", Continent: "Oceania", Currency: "AUD", CallingCode: "+686", Capital: "Tarawa"},
+	{ID: "KM", Alpha3: "COM", Numeric: "174", Name: "Comoros", Continent: "Africa", Currency: "KMF", CallingCode: "+269", Capital: "Moroni"},
+	{ID: "KN", Alpha3: "KNA", Numeric: "659", Name: "Saint Kitts and Nevis", Continent: "North America", Currency: "XCD", CallingCode: "+1869", Capital: "Basseterre"},
+	{ID: "KP", Alpha3: "PRK", Numeric: "408", Name: "Democratic People's Republic of Korea", Continent: "Asia", Currency: "KPW", CallingCode: "+850", Capital: "Pyongyang"},
+	{ID: "KR", Alpha3: "KOR", Numeric: "410", Name: "Republic of Korea", Continent: "Asia", Currency: "KRW", CallingCode: "+82", Capital: "Seoul"},
+	{ID: "KW", Alpha3: "KWT", Numeric: "414", Name: "Kuwait", Continent: "Asia", Currency: "KWD", CallingCode: "+965", Capital: "Kuwait City"},
+	{ID: "KY", Alpha3: "CYM", Numeric: "136", Name: "Cayman Islands", Continent: "North America", Currency: "KYD", CallingCode: "+1345", Capital: "George Town"},
+	{ID: "KZ", Alpha3: "KAZ", Numeric: "398", Name: "Kazakhstan", Continent: "Asia", Currency: "KZT", CallingCode: "+7", Capital: "Nur-Sultan"},
+	{ID: "LA", Alpha3: "LAO", Numeric: "418", Name: "Lao People's Democratic Republic", Continent: "Asia", Currency: "LAK", CallingCode: "+856", Capital: "Vientiane"},
+	{ID: "LB", Alpha3: "LBN", Numeric: "422", Name: "Lebanon", Continent: "Asia", Currency: "LBP", CallingCode: "+961", Capital: "Beirut"},
+	{ID: "LC", Alpha3: "LCA", Numeric: "662", Name: "Saint Lucia", Continent: "North America", Currency: "XCD", CallingCode: "+1758", Capital: "Castries"},
+	{ID: "LI", Alpha3: "LIE", Numeric: "438", Name: "Liechtenstein", Continent: "Europe", Currency: "CHF", CallingCode: "+423", Capital: "Vaduz"},
+	{ID: "LK", Alpha3: "LKA", Numeric: "144", Name: "Sri Lanka", Continent: "Asia", Currency: "LKR", CallingCode: "+94", Capital: "Colombo"},
+	{ID: "LR", Alpha3: "LBR", Numeric: "430", Name: "Liberia", Continent: "Africa", Currency: "LRD", CallingCode: "+231", Capital: "Monrovia"},
+	{ID: "LS", Alpha3: "LSO", Numeric: "426", Name: "Lesotho", Continent: "Africa", Currency: "LSL", CallingCode: "+266", Capital: "Maseru"},
+	{ID: "LT", Alpha3: "LTU", Numeric: "440", Name: "Lithuania", Continent: "Europe", Currency: "EUR", CallingCode: "+370", Capital: "Vilnius"},
+	{ID: "LU", Alpha3: "LUX", Numeric: "442", Name: "Luxembourg", Continent: "Europe", Currency: "EUR", CallingCode: "+352", Capital: "Luxembourg"},
+	{ID: "LV", Alpha3: "LVA", Numeric: "428", Name: "Latvia", Continent: "Europe", Currency: "EUR", CallingCode: "+371", Capital: "Riga"},
+	{ID: "LY", Alpha3: "LBY", Numeric: "434", Name: "Libya", Continent: "Africa", Currency: "LYD", CallingCode: "+218", Capital: "Tripoli"},
+	{ID: "MA", Alpha3: "MAR", Numeric: "504", Name: "Morocco", Continent: "Africa", Currency: "MAD", CallingCode: "+212", Capital: "Rabat"},
+	{ID: "MC", Alpha3: "MCO", Numeric: "492", Name: "Monaco", Continent: "Europe", Currency: "EUR", CallingCode: "+377", Capital: "Monaco"},
+	{ID: "MD", Alpha3: "MDA", Numeric: "498", Name: "Moldova (Republic of)", Continent: "Europe", Currency: "MDL", CallingCode: "+373", Capital: "Chisinau"},
+	{ID: "ME", Alpha3: "MNE", Numeric: "499", Name: "Montenegro", Continent: "Europe", Currency: "EUR", CallingCode: "+382", Capital: "Podgorica"},
+	{ID: "MF", Alpha3: "MAF", Numeric: "663", Name: "Saint Martin (French part)", Continent: "North America", Currency: "EUR", CallingCode: "+590", Capital: "Marigot"},
+	{ID: "MG", Alpha3: "MDG", Numeric: "450", Name: "Madagascar", Continent: "Africa", Currency: "MGA", CallingCode: "+261", Capital: "Antananarivo"},
+	{ID: "MH", Alpha3: "MHL", Numeric: "584", Name: "Marshall Islands", Continent: "Oceania", Currency: "USD", CallingCode: "+692", Capital: "Majuro"},
+	{ID: "MK", Alpha3: "MKD", Numeric: "807", Name: "North Macedonia", Continent: "Europe", Currency: "MKD", CallingCode: "+389", Capital: "Skopje"},
+	{ID: "ML", Alpha3: "MLI", Numeric: "466", Name: "Mali", Continent: "Africa", Currency: "XOF", CallingCode: "+223", Capital: "Bamako"},
+	{ID: "MM", Alpha3: "MMR", Numeric: "104", Name: "Myanmar", Continent: "Asia", Currency: "MMK", CallingCode: "+95", Capital: "Nay Pyi Taw"},
+	{ID: "MN", Alpha3: "MNG", Numeric: "496", Name: "Mongolia", Continent: "Asia", Currency: "MNT", CallingCode: "+976", Capital: "Ulaanbaatar"},
+	{ID: "MO", Alpha3: "MAC", Numeric: "446", Name: "Macao", Continent: "Asia", Currency: "MOP", CallingCode: "+853", Capital: "Macao"},
+	{ID: "MP", Alpha3: "MNP", Numeric: "580", Name: "Northern Mariana Islands", Continent: "Oceania", Currency: "USD", CallingCode: "+1670", Capital: "Saipan"},
+	{ID: "MQ", Alpha3: "MTQ", Numeric: "474", Name: "Martinique", Continent: "North America", Currency: "EUR", CallingCode: "+596", Capital: "Fort-de-France"},
+	{ID: "MR", Alpha3: "MRT", Numeric: "478", Name: "Mauritania", Continent: "Africa", Currency: "MRU", CallingCode: "+222", Capital: "Nouakchott"},
+	{ID: "MS", Alpha3: "MSR", Numeric: "500", Name: "Montserrat", Continent: "North America", Currency: "XCD", CallingCode: "+1664", Capital: "Plymouth"},
+	{ID: "MT", Alpha3: "MLT", Numeric: "470", Name: "Malta", Continent: "Europe", Currency: "EUR", CallingCode: "+356", Capital: "Valletta"},
+	{ID: "MU", Alpha3: "MUS", Numeric: "480", Name: "Mauritius", Continent: "Africa", Currency: "MUR", CallingCode: "+230", Capital: "Port Louis"},
+	{ID: "MV", Alpha3: "MDV", Numeric: "462", Name: "Maldives", Continent: "Asia", Currency: "MVR", CallingCode: "+960", Capital: "Male"},
+	{ID: "MW", Alpha3: "MWI", Numeric: "454", Name: "Malawi", Continent: "Africa", Currency: "MWK", CallingCode: "+265", Capital: "Lilongwe"},
+	{ID: "MX", Alpha3: "MEX", Numeric: "484", Name: "Mexico", Continent: "North America", Currency: "MXN", CallingCode: "+52", Capital: "Mexico City"},
+	{ID: "MY", Alpha3: "MYS", Numeric: "458", Name: "Malaysia", Continent: "Asia", Currency: "MYR", CallingCode: "+60", Capital: "Kuala Lumpur"},
+	{ID: "MZ", Alpha3: "MOZ", Numeric: "508", Name: "Mozambique", Continent: "Africa", Currency: "MZN", CallingCode: "+258", Capital: "Maputo"},
+	{ID: "NA", Alpha3: "NAM", Numeric: "516", Name: "Namibia", Continent: "Africa", Currency: "NAD", CallingCode: "+264", Capital: "Windhoek"},
+	{ID: "NC", Alpha3: "NCL", Numeric: "540", Name: "New Caledonia", Continent: "Oceania", Currency: "XPF", CallingCode: "+687", Capital: "Noumea"},
+	{ID: "NE", Alpha3: "NER", Numeric: "562", Name: "Niger", Continent: "Africa", Currency: "XOF", CallingCode: "+227", Capital: "Niamey"},
+	{ID: "NF", Alpha3: "NFK", Numeric: "574", Name: "Norfolk Island", Continent: "Oceania", Currency: "AUD", CallingCode: "+672", Capital: "Kingston Norfolk Island"},
+	{ID: "NG", Alpha3: "NGA", Numeric: "566", Name: "Nigeria", Continent: "Africa", Currency: "NGN", CallingCode: "+234", Capital: "Abuja"},
+	{ID: "NI", Alpha3: "NIC", Numeric: "558", Name: "Nicaragua", Continent: "North America", Currency: "NIO", CallingCode: "+505", Capital: "Managua"},
+	{ID: "NL", Alpha3: "NLD", Numeric: "528", Name: "Netherlands", Continent: "Europe", Currency: "EUR", CallingCode: "+31", Capital: "Amsterdam"},
+	{ID: "NO", Alpha3: "NOR", Numeric: "578", Name: "Norway", Continent: "Europe", Currency: "NOK", CallingCode: "+47", Capital: "Oslo"},
+	{ID: "NP", Alpha3: "NPL", Numeric: "524", Name: "Nepal", Continent: "Asia", Currency: "NPR", CallingCode: "+977", Capital: "Kathmandu"},
+	{ID: "NR", Alpha3: "NRU", Numeric: "520", Name: "Nauru", Continent: "Oceania", Currency: "AUD", CallingCode: "+674", Capital: "Yaren"},
+	{ID: "NU", Alpha3: "NIU", Numeric: "570", Name: "Niue", Continent: "Oceania", Currency: "NZD", CallingCode: "+683", Capital: "Alofi"},
+	{ID: "NZ", Alpha3: "NZL", Numeric: "554", Name: "New Zealand", Continent: "Oceania", Currency: "NZD", CallingCode: "+64", Capital: "Wellington"},
+	{ID: "OM", Alpha3: "OMN", Numeric: "512", Name: "Oman", Continent: "Asia", Currency: "OMR", CallingCode: "+968", Capital: "Muscat"},
+	{ID: "PA", Alpha3: "PAN", Numeric: "591", Name: "Panama", Continent: "North America", Currency: "PAB", CallingCode: "+507", Capital: "Panama City"},
+	{ID: "PE", Alpha3: "PER", Numeric: "604", Name: "Peru", Continent: "South America", Currency: "PEN", CallingCode: "+51", Capital: "Lima"},
+	{ID: "PF", Alpha3: "PYF", Numeric: "258", Name: "French Polynesia", Continent: "Oceania", Currency: "XPF", CallingCode: "+689", Capital: "Papeete"},
+	{ID: "PG", Alpha3: "PNG", Numeric: "598", Name: "Papua New Guinea", Continent: "Oceania", Currency: "PGK", CallingCode: "+675", Capital: "Port Moresby"},
+	{ID: "PH", Alpha3: "PHL", Numeric: "608", Name: "Philippines", Continent: "Asia", Currency: "PHP", CallingCode: "+63", Capital: "Manila"},
+	{ID: "PK", Alpha3: "PAK", Numeric: "586", Name: "Pakistan", Continent: "Asia", Currency: "PKR", CallingCode: "+92", Capital: "Islamabad"},
+	{ID: "PL", Alpha3: "POL", Numeric: "616", Name: "Poland", Continent: "Europe", Currency: "PLN", CallingCode: "+48", Capital: "Warsaw"},
+	{ID: "PM", Alpha3: "SPM", Numeric: "666", Name: "Saint Pierre and Miquelon", Continent: "North America", Currency: "EUR", CallingCode: "+508", Capital: "Saint-Pierre"},
+	{ID: "PN", Alpha3: "PCN", Numeric: "612", Name: "Pitcairn", Continent: "Oceania", Currency: "NZD", CallingCode: "+64", Capital: "Adamstown"},
+	{ID: "PR", Alpha3: "PRI", Numeric: "630", Name: "Puerto Rico", Continent: "North America", Currency: "USD", CallingCode: "+1787,+1939", Capital: "San Juan"},
+	{ID: "PS", Alpha3: "PSE", Numeric: "275", Name: "Palestinian Territory (Occupied)", Continent: "Asia", Currency: "ILS", CallingCode: "+970", Capital: "East Jerusalem"},
+	{ID: "PT", Alpha3: "PRT", Numeric: "620", Name: "Portugal", Continent: "Europe", Currency: "EUR", CallingCode: "+351", Capital: "Lisbon"},
+	{ID: "PW", Alpha3: "PLW", Numeric: "585", Name: "Palau", Continent: "Oceania", Currency: "USD", CallingCode: "+680", Capital: "Melekeok"},
+	{ID: "PY", Alpha3: "PRY", Numeric: "600", Name: "Paraguay", Continent: "South America", Currency: "PYG", CallingCode: "+595", Capital: "Asuncion"},
+	{ID: "QA", Alpha3: "QAT", Numeric: "634", Name: "Qatar", Continent: "Asia", Currency: "QAR", CallingCode: "+974", Capital: "Doha"},
+	{ID: "RE", Alpha3: "REU", Numeric: "638", Name: "Reunion", Continent: "Africa", Currency: "EUR", CallingCode: "+262", Capital: "Saint-Denis"},
+	{ID: "RO", Alpha3: "ROU", Numeric: "642", Name: "Romania", Continent: "Europe", Currency: "RON", CallingCode: "+40", Capital: "Bucharest"},
+	{ID: "RS", Alpha3: "SRB", Numeric: "688", Name: "Serbia", Continent: "Europe", Currency: "RSD", CallingCode: "+381", Capital: "Belgrade"},
+	{ID: "RU", Alpha3: "RUS", Numeric: "643", Name: "Russian Federation", Continent: "Europe", Currency: "RUB", CallingCode: "+7", Capital: "Moscow"},
+	{ID: "RW", Alpha3: "RWA", Numeric: "646", Name: "Rwanda", Continent: "Africa", Currency: "RWF", CallingCode: "+250", Capital: "Kigali"},
+	{ID: "SA", Alpha3: "SAU", Numeric: "682", Name: "Saudi Arabia", Continent: "Asia", Currency: "SAR", CallingCode: "+966", Capital: "Riyadh"},
+	{ID: "SB", Alpha3: "SLB", Numeric: "090", Name: "Solomon Islands", Continent: "Oceania", Currency: "SBD", CallingCode: "+677", Capital: "Honiara"},
+	{ID: "SC", Alpha3: "SYC", Numeric: "690", Name: "Seychelles", Continent: "Africa", Currency: "SCR", CallingCode: "+248", Capital: "Victoria"},
+	{ID: "SD", Alpha3: "SDN", Numeric: "729", Name: "Sudan", Continent: "Africa", Currency: "SDG", CallingCode: "+249", Capital: "Khartoum"},
+	{ID: "SE", Alpha3: "SWE", Numeric: "752", Name: "Sweden", Continent: "Europe", Currency: "SEK", CallingCode: "+46", Capital: "Stockholm"},
+	{ID: "SG", Alpha3: "SGP", Numeric: "702", Name: "Singapore", Continent: "Asia", Currency: "SGD", CallingCode: "+65", Capital: "Singapore"},
+	{ID: "SH", Alpha3: "SHN", Numeric: "654", Name: "Saint Helena", Continent: "Africa", Currency: "SHP", CallingCode: "+290", Capital: "Jamestown"},
+	{ID: "SI", Alpha3: "SVN", Numeric: "705", Name: "Slovenia", Continent: "Europe", Currency: "EUR", CallingCode: "+386", Capital: "Ljubljana"},
+	{ID: "SJ", Alpha3: "SJM", Numeric: "744", Name: "Svalbard and Jan Mayen Islands", Continent: "Europe", Currency: "NOK", CallingCode: "+4779", Capital: "Longyearbyen"},
+	{ID: "SK", Alpha3: "SVK", Numeric: "703", Name: "Slovakia", Continent: "Europe", Currency: "EUR", CallingCode: "+421", Capital: "Bratislava"},
+	{ID: "SL", Alpha3: "SLE", Numeric: "694", Name: "Sierra Leone", Continent: "Africa", Currency: "SLL", CallingCode: "+232", Capital: "Freetown"},
+	{ID: "SM", Alpha3: "SMR", Numeric: "674", Name: "San Marino", Continent: "Europe", Currency: "EUR", CallingCode: "+378", Capital: "San Marino"},
+	{ID: "SN", Alpha3: "SEN", Numeric: "686", Name: "Senegal", Continent: "Africa", Currency: "XOF", CallingCode: "+221", Capital: "Dakar"},
+	{ID: "SO", Alpha3: "SOM", Numeric: "706", Name: "Somalia", Continent: "Africa", Currency: "SOS", CallingCode: "+252", Capital: "Mogadishu"},
+	{ID: "SR", Alpha3: "SUR", Numeric: "740", Name: "Suriname", Continent: "South America", Currency: "SRD", CallingCode: "+597", Capital: "Paramaribo"},
+	{ID: "SS", Alpha3: "SSD", Numeric: "728", Name: "South Sudan", Continent: "Africa", Currency: "SSP", CallingCode: "+211", Capital: "Juba"},
+	{ID: "ST", Alpha3: "STP", Numeric: "678", Name: "Sao Tome and Principe", Continent: "Africa", Currency: "STN", CallingCode: "+239", Capital: "Sao Tome"},
+	{ID: "SV", Alpha3: "SLV", Numeric: "222", Name: "El Salvador", Continent: "North America", Currency: "SVC", CallingCode: "+503", Capital: "San Salvador"},
+	{ID: "SX", Alpha3: "SXM", Numeric: "534", Name: "Sint Maarten (Dutch part)", Continent: "North America", Currency: "ANG", CallingCode: "+1721", Capital: "Philipsburg"},
+	{ID: "SY", Alpha3: "SYR", Numeric: "760", Name: "Syrian Arab Republic", Continent: "Asia", Currency: "SYP", CallingCode: "+963", Capital: "Damascus"},
+	{ID: "SZ", Alpha3: "SWZ", Numeric: "748", Name: "Eswatini", Continent: "Africa", Currency: "SZL", CallingCode: "+268", Capital: "Mbabane"},
+	{ID: "TC", Alpha3: "TCA", Numeric: "796", Name: "Turks and Caicos Islands", Continent: "North America", Currency: "USD", CallingCode: "+1649", Capital: "Cockburn Town"},
+	{ID: "TD", Alpha3: "TCD", Numeric: "148", Name: "Chad", Continent: "Africa", Currency: "XAF", CallingCode: "+235", Capital: "N'Djamena"},
+	{ID: "TF", Alpha3: "ATF", Numeric: "260", Name: "French Southern Territories", Continent: "Antarctica", Currency: "EUR", CallingCode: "+1", Capital: "Port-aux-Francais"},
+	{ID: "TG", Alpha3: "TGO", Numeric: "768", Name: "Togo", Continent: "Africa", Currency: "XOF", CallingCode: "+228", Capital: "Lome"},
+	{ID: "TH", Alpha3: "THA", Numeric: "764", Name: "Thailand", Continent: "Asia", Currency: "THB", CallingCode: "+66", Capital: "Bangkok"},
+	{ID: "TJ", Alpha3: "TJK", Numeric: "762", Name: "Tajikistan", Continent: "Asia", Currency: "TJS", CallingCode: "+992", Capital: "Dushanbe"},
+	{ID: "TK", Alpha3: "TKL", Numeric: "772", Name: "Tokelau", Continent: "Oceania", Currency: "NZD", CallingCode: "+690", Capital: ""},
+	{ID: "TL", Alpha3: "TLS", Numeric: "626", Name: "Timor-Leste", Continent: "Asia", Currency: "USD", CallingCode: "+670", Capital: "Dili"},
+	{ID: "TM", Alpha3: "TKM", Numeric: "795", Name: "Turkmenistan", Continent: "Asia", Currency: "TMT", CallingCode: "+993", Capital: "Ashgabat"},
+	{ID: "TN", Alpha3: "TUN", Numeric: "788", Name: "Tunisia", Continent: "Africa", Currency: "TND", CallingCode: "+216", Capital: "Tunis"},
+	{ID: "TO", Alpha3: "TON", Numeric: "776", Name: "Tonga", Continent: "Oceania", Currency: "TOP", CallingCode: "+676", Capital: "Nuku'alofa"},
+	{ID: "TR", Alpha3: "TUR", Numeric: "792", Name: "Turkey", Continent: "Europe", Currency: "TRY", CallingCode: "+90", Capital: "Ankara"},
+	{ID: "TT", Alpha3: "TTO", Numeric: "780", Name: "Trinidad and Tobago", Continent: "North America", Currency: "TTD", CallingCode: "+1868", Capital: "Port of Spain"},
+	{ID: "TV", Alpha3: "TUV", Numeric: "798", Name: "Tuvalu", Continent: "Oceania", Currency: "AUD", CallingCode: "+688", Capital: "Funafuti"},
+	{ID: "TW", Alpha3: "TWN", Numeric: "158", Name: "Taiwan", Continent: "Asia", Currency: "TWD", CallingCode: "+886", Capital: "Taipei"},
+	{ID: "TZ", Alpha3: "TZA", Numeric: "834", Name: "Tanzania (United Republic of)", Continent: "Africa", Currency: "TZS", CallingCode: "+255", Capital: "Dodoma"},
+	{ID: "UA", Alpha3: "UKR", Numeric: "804", Name: "Ukraine", Continent: "Europe", Currency: "UAH", CallingCode: "+380", Capital: "Kyiv"},
+	{ID: "UG", Alpha3: "UGA", Numeric: "800", Name: "Uganda", Continent: "Africa", Currency: "UGX", CallingCode: "+256", Capital: "Kampala"},
+	{ID: "UM", Alpha3: "UMI", Numeric: "581", Name: "United States Minor Outlying Islands", Continent: "Oceania", Currency: "USD", CallingCode: "+1", Capital: ""},
+	{ID: "US", Alpha3: "USA", Numeric: "840", Name: "United States", Continent: "North America", Currency: "USD", CallingCode: "+1", Capital: "Washington"},
+	{ID: "UY", Alpha3: "URY", Numeric: "858", Name: "Uruguay", Continent: "South America", Currency: "UYI", CallingCode: "+598", Capital: "Montevideo"},
+	{ID: "UZ", Alpha3: "UZB", Numeric: "860", Name: "Uzbekistan", Continent: "Asia", Currency: "UZS", CallingCode: "+998", Capital: "Tashkent"},
+	{ID: "VA", Alpha3: "VAT", Numeric: "336", Name: "Holy See", Continent: "Europe", Currency: "EUR", CallingCode: "+3906698", Capital: "Vatican City"},
+	{ID: "VC", Alpha3: "VCT", Numeric: "670", Name: "Saint Vincent and the Grenadines", Continent: "North America", Currency: "XCD", CallingCode: "+1784", Capital: "Kingstown"},
+	{ID: "VE", Alpha3: "VEN", Numeric: "862", Name: "Venezuela", Continent: "South America", Currency: "VES", CallingCode: "+58", Capital: "Caracas"},
+	{ID: "VG", Alpha3: "VGB", Numeric: "092", Name: "Virgin Islands (British)", Continent: "North America", Currency: "USD", CallingCode: "+1284", Capital: "Road Town"},
+	{ID: "VI", Alpha3: "VIR", Numeric: "850", Name: "Virgin Islands (U.S.)", Continent: "North America", Currency: "USD", CallingCode: "+1340", Capital: "Charlotte Amalie"},
+	{ID: "VN", Alpha3: "VNM", Numeric: "704", Name: "Vietnam", Continent: "Asia", Currency: "VND", CallingCode: "+84", Capital: "Hanoi"},
+	{ID: "VU", Alpha3: "VUT", Numeric: "548", Name: "Vanuatu", Continent: "Oceania", Currency: "VUV", CallingCode: "+678", Capital: "Port Vila"},
+	{ID: "WF", Alpha3: "WLF", Numeric: "876", Name: "Wallis and Futuna Islands", Continent: "Oceania", Currency: "XPF", CallingCode: "+681", Capital: "Mata Utu"},
+	{ID: "WS", Alpha3: "WSM", Numeric: "882", Name: "Samoa", Continent: "Oceania", Currency: "WST", CallingCode: "+685", Capital: "Apia"},
+	{ID: "XK", Alpha3: "XKX", Numeric: "", Name: "Kosovo", Continent: "Europe", Currency: "EUR", CallingCode: "+383", Capital: "Pristina"},
+	{ID: "YE", Alpha3: "YEM", Numeric: "887", Name: "Yemen", Continent: "Asia", Currency: "YER", CallingCode: "+967", Capital: "Sanaa"},
+	{ID: "YT", Alpha3: "MYT", Numeric: "175", Name: "Mayotte", Continent: "Africa", Currency: "EUR", CallingCode: "+262269,+262639", Capital: "Mamoudzou"},
+	{ID: "ZA", Alpha3: "ZAF", Numeric: "710", Name: "South Africa", Continent: "Africa", Currency: "ZAR", CallingCode: "+27", Capital: "Pretoria"},
+	{ID: "ZM", Alpha3: "ZMB", Numeric: "894", Name: "Zambia", Continent: "Africa", Currency: "ZMW", CallingCode: "+260", Capital: "Lusaka"},
+	{ID: "ZW", Alpha3: "ZWE", Numeric: "716", Name: "Zimbabwe", Continent: "Africa", Currency: "ZWL", CallingCode: "+263", Capital: "Harare"},
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/drkaka/kkpanic"
 	"github.com/jackc/pgx"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 )
 
 var (
@@ -20,6 +22,9 @@ var (
 	ErrCityExisted = errors.New("City is already existed.")
 )
 
+// regionLevels the administrative area levels recorded as regions.
+const regionLevels = 2
+
 // dbPool the pgx database pool.
 var dbPool *pgx.ConnPool
 
@@ -31,9 +36,43 @@ func use(pool *pgx.ConnPool, langs []string) {
 	kkpanic.P(err)
 
 	prepareCountry(tx, langs)
+	seedCountries(tx, langs)
+	prepareRegion(tx, langs)
 	prepareCity(tx, langs)
 
 	kkpanic.P(tx.Commit())
+
+	prepareCollations(langs)
+	prepareSearch()
+}
+
+// collations the collations used to sort the names of each language.
+var collations = make(map[string]string)
+
+// prepareCollations to use the ICU collation of each language if the database has it.
+func prepareCollations(langs []string) {
+	for _, one := range langs {
+		if _, ok := collations[one]; ok {
+			continue
+		}
+
+		var name string
+		err := dbPool.QueryRow("SELECT collname FROM pg_collation WHERE collname=$1", one+"-x-icu").Scan(&name)
+		if err == pgx.ErrNoRows {
+			continue
+		}
+		kkpanic.P(err)
+		collations[one] = name
+	}
+}
+
+// getCollateClause to get the COLLATE clause of the language, empty to use the database default.
+func getCollateClause(lang string) string {
+	collation, ok := collations[lang]
+	if !ok || len(collation) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` COLLATE "%s"`, strings.Replace(collation, `"`, `""`, -1))
 }
 
 // checkDBColumnExisted to check whether the column is existed in table.
@@ -56,6 +95,17 @@ func checkDBColumnExisted(table, column string) (bool, error) {
 	return false, nil
 }
 
+// addDBColumn to add the column to table if not existed.
+func addDBColumn(tx *pgx.Tx, table, column, tp string) {
+	existed, err := checkDBColumnExisted(table, column)
+	kkpanic.P(err)
+
+	if !existed {
+		_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD %s %s;", table, column, tp))
+		kkpanic.P(err)
+	}
+}
+
 func prepareCity(tx *pgx.Tx, langs []string) {
 	var err error
 	// create city info table
@@ -69,6 +119,38 @@ func prepareCity(tx *pgx.Tx, langs []string) {
 	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS index_city_info_country_id ON city_info (country_id);")
 	kkpanic.P(err)
 
+	// setup the region column, the most specific region of the city
+	addDBColumn(tx, "city_info", "region_id", "bigint")
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS index_city_info_region_id ON city_info (region_id);")
+	kkpanic.P(err)
+
+	// setup the name type column, the address component type of the name
+	addDBColumn(tx, "city_info", "name_type", "text")
+
+	// setup the timezone column, IANA timezone such as Asia/Shanghai
+	addDBColumn(tx, "city_info", "timezone", "text")
+
+	// setup the location column, used to re-resolve an obsolete placeid
+	addDBColumn(tx, "city_info", "lat", "double precision")
+	addDBColumn(tx, "city_info", "lng", "double precision")
+
+	// create the table to map the cell of locations to the city, used by the batch lookup
+	s = `CREATE TABLE IF NOT EXISTS city_cell_map (
+	cell text primary key,
+	placeid text not null);`
+
+	_, err = tx.Exec(s)
+	kkpanic.P(err)
+
+	// create the table to map obsolete placeid to the current one
+	s = `CREATE TABLE IF NOT EXISTS city_placeid_map (
+	old_id text primary key,
+	new_id text not null);`
+
+	_, err = tx.Exec(s)
+	kkpanic.P(err)
+
 	// setup the language name and address column
 	for _, one := range langs {
 		nameColumn, addressColumn := getCityColumnNames(one)
@@ -82,6 +164,8 @@ func prepareCity(tx *pgx.Tx, langs []string) {
 			_, err = tx.Exec(fmt.Sprintf("ALTER TABLE city_info ADD %s text;", addressColumn))
 			kkpanic.P(err)
 		}
+
+		addDBColumn(tx, "city_info", getCityLongNameColumn(one), "text")
 	}
 }
 
@@ -95,6 +179,11 @@ func prepareCountry(tx *pgx.Tx, langs []string) {
 	_, err := tx.Exec(s)
 	kkpanic.P(err)
 
+	// setup the metadata column
+	for _, one := range countryMetaColumns {
+		addDBColumn(tx, "country_info", one, "text")
+	}
+
 	// setup the language name and address column
 	for _, one := range langs {
 		nameColumn := getCountryColumnName(one)
@@ -105,6 +194,89 @@ func prepareCountry(tx *pgx.Tx, langs []string) {
 			_, err := tx.Exec(fmt.Sprintf("ALTER TABLE country_info ADD %s text;", nameColumn))
 			kkpanic.P(err)
 		}
+
+		addDBColumn(tx, "country_info", getCountryShortNameColumn(one), "text")
+		addDBColumn(tx, "country_info", getCountryCLDRColumnName(one), "text")
+	}
+}
+
+// countryMetaColumns the metadata columns of country_info.
+var countryMetaColumns = []string{"alpha3", "numeric_code", "continent", "currency", "calling_code", "capital"}
+
+// seedCountries to record all the countries with metadata and CLDR names.
+func seedCountries(tx *pgx.Tx, langs []string) {
+	columns := append([]string{"id"}, countryMetaColumns...)
+	var updates []string
+	for _, one := range countryMetaColumns {
+		updates = append(updates, fmt.Sprintf("%[1]s=EXCLUDED.%[1]s", one))
+	}
+
+	namers := make([]display.Namer, len(langs))
+	for i, one := range langs {
+		namers[i] = display.Regions(language.Make(one))
+
+		column := getCountryCLDRColumnName(one)
+		columns = append(columns, column)
+		updates = append(updates, fmt.Sprintf("%[1]s=EXCLUDED.%[1]s", column))
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	s := fmt.Sprintf("INSERT INTO country_info(%s) VALUES(%s) ON CONFLICT (id) DO UPDATE SET %s",
+		strings.Join(columns, ","), strings.Join(placeholders, ","), strings.Join(updates, ","))
+
+	for _, one := range countryData {
+		args := []interface{}{one.ID, one.Alpha3, one.Numeric, one.Continent, one.Currency, one.CallingCode, one.Capital}
+		for i, lang := range langs {
+			args = append(args, getCLDRCountryName(namers[i], one, lang))
+		}
+
+		_, err := tx.Exec(s, args...)
+		kkpanic.P(err)
+	}
+}
+
+// getCLDRCountryName to get the CLDR name of the country.
+// The English name of the dataset is used if CLDR has no English name.
+func getCLDRCountryName(namer display.Namer, country Country, lang string) string {
+	var name string
+	if namer != nil {
+		if region, err := language.ParseRegion(country.ID); err == nil {
+			name = namer.Name(region)
+		}
+	}
+
+	if len(name) == 0 && lang == "en" {
+		return country.Name
+	}
+	return name
+}
+
+func prepareRegion(tx *pgx.Tx, langs []string) {
+	// create region info table
+	// level 1 is administrative_area_level_1 such as province or state.
+	// level 2 is administrative_area_level_2 and its parent is the level 1 region.
+	s := `CREATE TABLE IF NOT EXISTS region_info (
+	id bigserial primary key,
+	country_id text not null,
+	parent_id bigint,
+	level integer not null);`
+
+	_, err := tx.Exec(s)
+	kkpanic.P(err)
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS index_region_info_country_id ON region_info (country_id);")
+	kkpanic.P(err)
+
+	_, err = tx.Exec("CREATE INDEX IF NOT EXISTS index_region_info_parent_id ON region_info (parent_id);")
+	kkpanic.P(err)
+
+	// setup the language name column
+	for _, one := range langs {
+		addDBColumn(tx, "region_info", getRegionColumnName(one), "text")
 	}
 }
 
@@ -113,6 +285,22 @@ func getCityColumnNames(lang string) (string, string) {
 	return fmt.Sprintf("name_%s", lang), fmt.Sprintf("address_%s", lang)
 }
 
+// getCityLongNameColumn to get the name of city long name column.
+// The name column returned by getCityColumnNames holds the short name.
+func getCityLongNameColumn(lang string) string {
+	return fmt.Sprintf("long_name_%s", lang)
+}
+
+// getCityNameColumn to get the name column expression of the policy.
+func getCityNameColumn(lang string, policy NamePolicy) string {
+	nameColumn, _ := getCityColumnNames(lang)
+	if policy == NameLong {
+		// the cities recorded before the long names have no long name
+		return fmt.Sprintf("COALESCE(NULLIF(%s,''),%s)", getCityLongNameColumn(lang), nameColumn)
+	}
+	return nameColumn
+}
+
 // addCityInfo to add a city.
 func addCityInfo(placeid, country, name, address, lang string) error {
 	nameColumn, addressColumn := getCityColumnNames(lang)
@@ -129,8 +317,9 @@ func addCityInfo(placeid, country, name, address, lang string) error {
 
 // getCityInfo to get city information of a certain language.
 // Return place existed, name, address, error.
-func getCityInfo(placeid, lang string) (bool, string, string, error) {
-	nameColumn, addressColumn := getCityColumnNames(lang)
+func getCityInfo(placeid, lang string, policy NamePolicy) (bool, string, string, error) {
+	_, addressColumn := getCityColumnNames(lang)
+	nameColumn := getCityNameColumn(lang, policy)
 
 	s := fmt.Sprintf("SELECT %s,%s FROM city_info WHERE placeid=$1", nameColumn, addressColumn)
 
@@ -154,13 +343,242 @@ func updateCityInfo(placeid, name, address, lang string) error {
 	return err
 }
 
+// updateCityLongName to update the long name of a certain language.
+func updateCityLongName(placeid, longName, lang string) error {
+	s := fmt.Sprintf("UPDATE city_info SET %s=$1 WHERE placeid=$2", getCityLongNameColumn(lang))
+
+	_, err := dbPool.Exec(s, longName, placeid)
+	return err
+}
+
+// updateCityLocation to update the location of a city.
+func updateCityLocation(placeid string, lat, lng float64) error {
+	_, err := dbPool.Exec("UPDATE city_info SET lat=$1,lng=$2 WHERE placeid=$3", lat, lng, placeid)
+	return err
+}
+
+// updateCityNameType to update the address component type of the city name.
+func updateCityNameType(placeid, nameType string) error {
+	_, err := dbPool.Exec("UPDATE city_info SET name_type=$1 WHERE placeid=$2", nameType, placeid)
+	return err
+}
+
+// getCityNameType to get the address component type of the city name.
+// Return the type, empty if not recorded, error.
+func getCityNameType(placeid string) (string, error) {
+	var nameType pgx.NullString
+	if err := dbPool.QueryRow("SELECT name_type FROM city_info WHERE placeid=$1", placeid).Scan(&nameType); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return nameType.String, nil
+}
+
+// updateCityTimezone to update the timezone of a city.
+func updateCityTimezone(placeid, zone string) error {
+	_, err := dbPool.Exec("UPDATE city_info SET timezone=$1 WHERE placeid=$2", zone, placeid)
+	return err
+}
+
+// getCityTimezone to get the timezone of a city.
+// Return city existed, timezone, empty if not recorded, error.
+func getCityTimezone(placeid string) (bool, string, error) {
+	var zone pgx.NullString
+	if err := dbPool.QueryRow("SELECT timezone FROM city_info WHERE placeid=$1", placeid).Scan(&zone); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, "", nil
+		}
+		return false, "", err
+	}
+	return true, zone.String, nil
+}
+
+// getCityTimezones to get the timezones of the cities.
+// Return timezones by placeid, the cities not recorded or without timezone are absent, error.
+func getCityTimezones(placeIDs []string) (map[string]string, error) {
+	zones := make(map[string]string)
+	if len(placeIDs) == 0 {
+		return zones, nil
+	}
+
+	placeholders := make([]string, len(placeIDs))
+	args := make([]interface{}, len(placeIDs))
+	for i, one := range placeIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = one
+	}
+
+	s := fmt.Sprintf("SELECT placeid,timezone FROM city_info WHERE placeid IN (%s) AND timezone IS NOT NULL", strings.Join(placeholders, ","))
+	rows, err := dbPool.Query(s, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var placeID, zone string
+		if err := rows.Scan(&placeID, &zone); err != nil {
+			return nil, err
+		}
+		zones[placeID] = zone
+	}
+	return zones, rows.Err()
+}
+
+// getCityLocation to get the location of a city.
+// Return location existed, lat, lng, error.
+func getCityLocation(placeid string) (bool, float64, float64, error) {
+	var lat, lng pgx.NullFloat64
+	if err := dbPool.QueryRow("SELECT lat,lng FROM city_info WHERE placeid=$1", placeid).Scan(&lat, &lng); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, 0, 0, nil
+		}
+		return false, 0, 0, err
+	}
+	return lat.Valid && lng.Valid, lat.Float64, lng.Float64, nil
+}
+
+// getCurrentPlaceID to get the current placeid of a possibly obsolete one.
+// Return the placeid itself if it is not recorded as obsolete.
+func getCurrentPlaceID(placeid string) (string, error) {
+	var newID string
+	if err := dbPool.QueryRow("SELECT new_id FROM city_placeid_map WHERE old_id=$1", placeid).Scan(&newID); err != nil {
+		if err == pgx.ErrNoRows {
+			return placeid, nil
+		}
+		return "", err
+	}
+	return newID, nil
+}
+
+// replaceCityPlaceID to replace an obsolete placeid with the new one.
+// The mapping is recorded so that the old one still resolves to the city.
+func replaceCityPlaceID(oldID, newID string) error {
+	tx, err := dbPool.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the ids which were mapped to the old one should follow the new one
+	if _, err = tx.Exec("UPDATE city_placeid_map SET new_id=$1 WHERE new_id=$2", newID, oldID); err != nil {
+		return err
+	}
+
+	s := `INSERT INTO city_placeid_map(old_id,new_id) VALUES($1,$2)
+	ON CONFLICT (old_id) DO UPDATE SET new_id=EXCLUDED.new_id`
+	if _, err = tx.Exec(s, oldID, newID); err != nil {
+		return err
+	}
+
+	// the new placeid may be already recorded, then the old city is redundant
+	var existed bool
+	if err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM city_info WHERE placeid=$1)", newID).Scan(&existed); err != nil {
+		return err
+	}
+
+	if existed {
+		_, err = tx.Exec("DELETE FROM city_info WHERE placeid=$1", oldID)
+	} else {
+		_, err = tx.Exec("UPDATE city_info SET placeid=$1 WHERE placeid=$2", newID, oldID)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// getCellPlaceID to get the placeid of the city recorded for the cell.
+// Return cell existed, placeid, error.
+func getCellPlaceID(cell string) (bool, string, error) {
+	var placeid string
+	if err := dbPool.QueryRow("SELECT placeid FROM city_cell_map WHERE cell=$1", cell).Scan(&placeid); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, "", nil
+		}
+		return false, "", err
+	}
+	return true, placeid, nil
+}
+
+// setCellPlaceID to record the placeid of the city for the cell.
+func setCellPlaceID(cell, placeid string) error {
+	_, err := dbPool.Exec("INSERT INTO city_cell_map(cell,placeid) VALUES($1,$2) ON CONFLICT (cell) DO UPDATE SET placeid=EXCLUDED.placeid", cell, placeid)
+	return err
+}
+
+// deleteCityInfo to delete a city.
+// Return whether the city existed, error.
+func deleteCityInfo(placeid string) (bool, error) {
+	tag, err := dbPool.Exec("DELETE FROM city_info WHERE placeid=$1", placeid)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// countRows to count the rows of the query.
+func countRows(s string, args ...interface{}) (int64, error) {
+	var count int64
+	err := dbPool.QueryRow(s, args...).Scan(&count)
+	return count, err
+}
+
+// getStats to get the statistics of the recorded data.
+func getStats(langs []string) (Stats, error) {
+	var stats Stats
+	var err error
+
+	if stats.Countries, err = countRows("SELECT count(*) FROM country_info"); err != nil {
+		return stats, err
+	}
+
+	if stats.CountriesWithCities, err = countRows("SELECT count(DISTINCT country_id) FROM city_info"); err != nil {
+		return stats, err
+	}
+
+	if stats.Cities, err = countRows("SELECT count(*) FROM city_info"); err != nil {
+		return stats, err
+	}
+
+	if stats.Regions, err = countRows("SELECT count(*) FROM region_info"); err != nil {
+		return stats, err
+	}
+
+	if stats.ReplacedPlaceIDs, err = countRows("SELECT count(*) FROM city_placeid_map"); err != nil {
+		return stats, err
+	}
+
+	stats.CitiesWithName = make(map[string]int64)
+	for _, one := range langs {
+		nameColumn, _ := getCityColumnNames(one)
+		if stats.CitiesWithName[one], err = countRows(fmt.Sprintf("SELECT count(*) FROM city_info WHERE COALESCE(%s,'')<>''", nameColumn)); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
 // getCountryCities to get city information in one country.
 // Return city ids, names, addresses, error
-func getCountryCities(countryID, lang string) ([]string, []string, []string, error) {
-	nameColumn, addressColumn := getCityColumnNames(lang)
+func getCountryCities(countryID, lang string, policy NamePolicy) ([]string, []string, []string, error) {
+	return queryCities(lang, policy, "WHERE country_id=$1 ORDER BY placeid", countryID)
+}
 
-	s := fmt.Sprintf("SELECT placeid,%s,%s FROM city_info WHERE country_id=$1", nameColumn, addressColumn)
-	rows, _ := dbPool.Query(s, countryID)
+// queryCities to get city information with the query condition.
+// Return city ids, names, addresses, error
+func queryCities(lang string, policy NamePolicy, condition string, args ...interface{}) ([]string, []string, []string, error) {
+	_, addressColumn := getCityColumnNames(lang)
+	nameColumn := getCityNameColumn(lang, policy)
+
+	s := fmt.Sprintf("SELECT placeid,%s,%s FROM city_info %s", nameColumn, addressColumn, condition)
+	rows, err := dbPool.Query(s, args...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer rows.Close()
 
 	var placeIDs, cityNames, cityAddresses []string
 	for rows.Next() {
@@ -174,7 +592,79 @@ func getCountryCities(countryID, lang string) ([]string, []string, []string, err
 		cityNames = append(cityNames, cityName.String)
 		cityAddresses = append(cityAddresses, cityAddress.String)
 	}
-	return placeIDs, cityNames, cityAddresses, nil
+	return placeIDs, cityNames, cityAddresses, rows.Err()
+}
+
+// getListClause to get the WHERE, ORDER BY and LIMIT clause of a listing.
+// nameColumn is the name expression and idColumn is the unique column to sort by.
+// The conditions and args of the query are extended with the options.
+// One more row than the limit is selected to know whether there is a next page.
+func getListClause(nameColumn, idColumn, lang string, opts ListOptions, conditions []string, args []interface{}) (string, []interface{}, error) {
+	cursor, err := decodeCursor(opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	nameKey := fmt.Sprintf("COALESCE(%s,'')%s", nameColumn, getCollateClause(lang))
+	if opts.NamedOnly {
+		conditions = append(conditions, fmt.Sprintf("COALESCE(%s,'')<>''", nameColumn))
+	}
+
+	direction, compare := "ASC", ">"
+	if opts.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	if cursor != nil {
+		if opts.Sort == SortByName {
+			args = append(args, cursor.Name, cursor.ID)
+			conditions = append(conditions, fmt.Sprintf("(%[1]s%[2]s$%[3]d OR (%[1]s=$%[3]d AND %[4]s%[2]s$%[5]d))",
+				nameKey, compare, len(args)-1, idColumn, len(args)))
+		} else {
+			args = append(args, cursor.ID)
+			conditions = append(conditions, fmt.Sprintf("%s%s$%d", idColumn, compare, len(args)))
+		}
+	}
+
+	var clause string
+	if len(conditions) > 0 {
+		clause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	if opts.Sort == SortByName {
+		clause += fmt.Sprintf(" ORDER BY %s %s,%s %s", nameKey, direction, idColumn, direction)
+	} else {
+		clause += fmt.Sprintf(" ORDER BY %s %s", idColumn, direction)
+	}
+
+	if opts.Limit > 0 {
+		args = append(args, opts.Limit+1)
+		clause += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	return clause, args, nil
+}
+
+// listCities to get one page of the cities with the query condition.
+func listCities(lang string, opts ListOptions, condition string, args ...interface{}) (CityPage, error) {
+	clause, args, err := getListClause(getCityNameColumn(lang, opts.Policy), "placeid", lang, opts, []string{condition}, args)
+	if err != nil {
+		return CityPage{}, err
+	}
+
+	var page CityPage
+	if page.PlaceIDs, page.Names, page.Addresses, err = queryCities(lang, opts.Policy, clause, args...); err != nil {
+		return page, err
+	}
+
+	if opts.Limit > 0 && len(page.PlaceIDs) > opts.Limit {
+		page.PlaceIDs = page.PlaceIDs[:opts.Limit]
+		page.Names = page.Names[:opts.Limit]
+		page.Addresses = page.Addresses[:opts.Limit]
+
+		last := opts.Limit - 1
+		page.NextCursor = listCursor{Sort: opts.Sort, Descending: opts.Descending, Name: page.Names[last], ID: page.PlaceIDs[last]}.encode()
+	}
+	return page, nil
 }
 
 // getCountryColumnName to get the name of country name column.
@@ -182,6 +672,27 @@ func getCountryColumnName(lang string) string {
 	return fmt.Sprintf("name_%s", lang)
 }
 
+// getCountryShortNameColumn to get the name of country short name column.
+// The name column returned by getCountryColumnName holds the long name.
+func getCountryShortNameColumn(lang string) string {
+	return fmt.Sprintf("short_name_%s", lang)
+}
+
+// getCountryCLDRColumnName to get the name of country CLDR name column.
+// The CLDR name is used when the name from Google is not recorded.
+func getCountryCLDRColumnName(lang string) string {
+	return fmt.Sprintf("cldr_name_%s", lang)
+}
+
+// getCountryNameColumn to get the name column expression of the policy.
+func getCountryNameColumn(lang string, policy NamePolicy) string {
+	// the short name is only known after the country is requested from Google
+	if policy == NameShort {
+		return fmt.Sprintf("COALESCE(NULLIF(%s,''),NULLIF(%s,''),%s)", getCountryShortNameColumn(lang), getCountryColumnName(lang), getCountryCLDRColumnName(lang))
+	}
+	return fmt.Sprintf("COALESCE(NULLIF(%s,''),%s)", getCountryColumnName(lang), getCountryCLDRColumnName(lang))
+}
+
 // checkCountryID to check whether country id is valid.
 func checkCountryID(id string) error {
 	if len(id) != 2 {
@@ -242,12 +753,24 @@ func updateCountryInfo(id, name, lang string) error {
 	return err
 }
 
+// updateCountryShortName to update the short name of a certain language.
+func updateCountryShortName(id, shortName, lang string) error {
+	s := fmt.Sprintf("UPDATE country_info SET %s=$1 WHERE id=$2", getCountryShortNameColumn(lang))
+
+	_, err := dbPool.Exec(s, shortName, strings.ToUpper(id))
+	return err
+}
+
 // getCountries to get country and their names.
-func getCountries(lang string) ([]string, []string, error) {
-	nameColumn := getCountryColumnName(lang)
+func getCountries(lang string, policy NamePolicy) ([]string, []string, error) {
+	nameColumn := getCountryNameColumn(lang, policy)
 
-	s := fmt.Sprintf("SELECT id,%s FROM country_info", nameColumn)
-	rows, _ := dbPool.Query(s)
+	s := fmt.Sprintf("SELECT id,%s FROM country_info ORDER BY id", nameColumn)
+	rows, err := dbPool.Query(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
 
 	var countries []string
 	var countryNames []string
@@ -264,3 +787,578 @@ func getCountries(lang string) ([]string, []string, error) {
 	}
 	return countries, countryNames, nil
 }
+
+// getCountry to get a country with its metadata.
+func getCountry(id, lang string, policy NamePolicy) (bool, Country, error) {
+	if err := checkCountryID(id); err != nil {
+		return false, Country{}, err
+	}
+
+	countries, err := queryCountries(lang, policy, "WHERE id=$1", strings.ToUpper(id))
+	if err != nil || len(countries) == 0 {
+		return false, Country{}, err
+	}
+	return true, countries[0], nil
+}
+
+// listCountries to get one page of the countries with their metadata.
+func listCountries(lang string, opts ListOptions) (CountryPage, error) {
+	clause, args, err := getListClause(getCountryNameColumn(lang, opts.Policy), "id", lang, opts, nil, nil)
+	if err != nil {
+		return CountryPage{}, err
+	}
+
+	var page CountryPage
+	if page.Countries, err = queryCountries(lang, opts.Policy, clause, args...); err != nil {
+		return page, err
+	}
+
+	if opts.Limit > 0 && len(page.Countries) > opts.Limit {
+		page.Countries = page.Countries[:opts.Limit]
+
+		last := page.Countries[opts.Limit-1]
+		page.NextCursor = listCursor{Sort: opts.Sort, Descending: opts.Descending, Name: last.Name, ID: last.ID}.encode()
+	}
+	return page, nil
+}
+
+// getCountriesInfo to get all the countries with their metadata.
+func getCountriesInfo(lang string, policy NamePolicy) ([]Country, error) {
+	return queryCountries(lang, policy, "ORDER BY id")
+}
+
+// queryCountries to get the countries with metadata with the query condition.
+func queryCountries(lang string, policy NamePolicy, condition string, args ...interface{}) ([]Country, error) {
+	nameColumn := getCountryNameColumn(lang, policy)
+
+	s := fmt.Sprintf("SELECT id,%s,%s FROM country_info %s", nameColumn, strings.Join(countryMetaColumns, ","), condition)
+	rows, err := dbPool.Query(s, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var countries []Country
+	for rows.Next() {
+		var id string
+		var name, alpha3, numeric, continent, currency, callingCode, capital pgx.NullString
+
+		if err := rows.Scan(&id, &name, &alpha3, &numeric, &continent, &currency, &callingCode, &capital); err != nil {
+			return countries, err
+		}
+
+		countries = append(countries, Country{
+			ID:          id,
+			Alpha3:      alpha3.String,
+			Numeric:     numeric.String,
+			Name:        name.String,
+			Continent:   continent.String,
+			Currency:    currency.String,
+			CallingCode: callingCode.String,
+			Capital:     capital.String,
+		})
+	}
+	return countries, rows.Err()
+}
+
+// getRegionColumnName to get the name of region name column.
+func getRegionColumnName(lang string) string {
+	return fmt.Sprintf("name_%s", lang)
+}
+
+// nullRegionID to get the database value of region id, 0 is null.
+func nullRegionID(id int64) pgx.NullInt64 {
+	return pgx.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// addRegion to add a region.
+// parentID is 0 if the region has no parent.
+// Return the region id, error.
+func addRegion(countryID string, parentID int64, level int, name, lang string) (int64, error) {
+	nameColumn := getRegionColumnName(lang)
+
+	s := fmt.Sprintf("INSERT INTO region_info(country_id,parent_id,level,%s) VALUES($1,$2,$3,$4) RETURNING id", nameColumn)
+
+	var id int64
+	err := dbPool.QueryRow(s, strings.ToUpper(countryID), nullRegionID(parentID), level, name).Scan(&id)
+	return id, err
+}
+
+// findRegion to find a region with its name of a certain language.
+// Return region existed, region id, error.
+func findRegion(countryID string, parentID int64, level int, name, lang string) (bool, int64, error) {
+	nameColumn := getRegionColumnName(lang)
+
+	s := fmt.Sprintf("SELECT id FROM region_info WHERE country_id=$1 AND parent_id IS NOT DISTINCT FROM $2 AND level=$3 AND %s=$4 LIMIT 1", nameColumn)
+
+	var id int64
+	if err := dbPool.QueryRow(s, strings.ToUpper(countryID), nullRegionID(parentID), level, name).Scan(&id); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+	return true, id, nil
+}
+
+// hasUnnamedRegion to check whether there is a region without the name of lang but with the name of other.
+func hasUnnamedRegion(countryID string, parentID int64, level int, lang, other string) (bool, error) {
+	s := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM region_info WHERE country_id=$1 AND parent_id IS NOT DISTINCT FROM $2 AND level=$3 AND %s IS NULL AND %s IS NOT NULL)",
+		getRegionColumnName(lang), getRegionColumnName(other))
+
+	var existed bool
+	err := dbPool.QueryRow(s, strings.ToUpper(countryID), nullRegionID(parentID), level).Scan(&existed)
+	return existed, err
+}
+
+// getRegionInfo to get region information of a certain language.
+// Return region existed, level, parent id, name, error.
+func getRegionInfo(id int64, lang string) (bool, int, int64, string, error) {
+	nameColumn := getRegionColumnName(lang)
+
+	s := fmt.Sprintf("SELECT level,parent_id,%s FROM region_info WHERE id=$1", nameColumn)
+
+	var level int32
+	var parentID pgx.NullInt64
+	var name pgx.NullString
+	if err := dbPool.QueryRow(s, id).Scan(&level, &parentID, &name); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, 0, 0, "", nil
+		}
+		return false, 0, 0, "", err
+	}
+	return true, int(level), parentID.Int64, name.String, nil
+}
+
+// updateRegionInfo to update a certain language.
+func updateRegionInfo(id int64, name, lang string) error {
+	nameColumn := getRegionColumnName(lang)
+
+	s := fmt.Sprintf("UPDATE region_info SET %s=$1 WHERE id=$2", nameColumn)
+
+	_, err := dbPool.Exec(s, name, id)
+	return err
+}
+
+// getRegions to get regions and their names with the query condition.
+func getRegions(lang, condition string, args ...interface{}) ([]int64, []string, error) {
+	nameColumn := getRegionColumnName(lang)
+
+	s := fmt.Sprintf("SELECT id,%s FROM region_info WHERE %s", nameColumn, condition)
+	rows, err := dbPool.Query(s, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	var names []string
+	for rows.Next() {
+		var id int64
+		var name pgx.NullString
+
+		if err := rows.Scan(&id, &name); err != nil {
+			return ids, names, err
+		}
+
+		ids = append(ids, id)
+		names = append(names, name.String)
+	}
+	return ids, names, rows.Err()
+}
+
+// getCountryRegions to get the level 1 regions in one country.
+// Return region ids, names, error
+func getCountryRegions(countryID, lang string) ([]int64, []string, error) {
+	return getRegions(lang, "country_id=$1 AND level=1", strings.ToUpper(countryID))
+}
+
+// getSubregions to get the regions whose parent is the region.
+// Return region ids, names, error
+func getSubregions(regionID int64, lang string) ([]int64, []string, error) {
+	return getRegions(lang, "parent_id=$1", regionID)
+}
+
+// updateCityRegion to update the most specific region of a city.
+func updateCityRegion(placeid string, regionID int64) error {
+	_, err := dbPool.Exec("UPDATE city_info SET region_id=$1 WHERE placeid=$2", nullRegionID(regionID), placeid)
+	return err
+}
+
+// getCityRegionID to get the most specific region of a city.
+// Return region id, 0 if the city has no region, error.
+func getCityRegionID(placeid string) (int64, error) {
+	var regionID pgx.NullInt64
+	if err := dbPool.QueryRow("SELECT region_id FROM city_info WHERE placeid=$1", placeid).Scan(&regionID); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return regionID.Int64, nil
+}
+
+// getRegionCities to get city information in one region, including its subregions.
+// Return city ids, names, addresses, error
+func getRegionCities(regionID int64, lang string, policy NamePolicy) ([]string, []string, []string, error) {
+	return queryCities(lang, policy, "WHERE region_id=$1 OR region_id IN (SELECT id FROM region_info WHERE parent_id=$1)", regionID)
+}
+
+// searchTrigram whether pg_trgm is available to search cities by similarity.
+var searchTrigram bool
+
+// searchUnaccent whether unaccent is available to search cities accent insensitive.
+var searchUnaccent bool
+
+// prepareExtension to create the extension if it is not existed.
+// Return whether the extension is available, it is not if the user has no privilege to create it.
+func prepareExtension(name string) bool {
+	if existed, err := countRows("SELECT count(*) FROM pg_extension WHERE extname=$1", name); err == nil && existed > 0 {
+		return true
+	}
+
+	_, err := dbPool.Exec(fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s;", name))
+	return err == nil
+}
+
+// getSearchNormalize to get the expression to normalize the text for searching.
+func getSearchNormalize(expr string) string {
+	if searchUnaccent {
+		return fmt.Sprintf("lower(unaccent(%s))", expr)
+	}
+	return fmt.Sprintf("lower(%s)", expr)
+}
+
+// prepareSearch to maintain the search_text column of city_info with a trigger.
+// search_text is the normalized names and addresses in all the languages.
+func prepareSearch() {
+	searchTrigram = prepareExtension("pg_trgm")
+	searchUnaccent = prepareExtension("unaccent")
+
+	langs, err := getTableLangs("city_info")
+	kkpanic.P(err)
+
+	var columns []string
+	for _, one := range langs {
+		nameColumn, addressColumn := getCityColumnNames(one)
+		columns = append(columns, "NEW."+nameColumn, "NEW."+getCityLongNameColumn(one), "NEW."+addressColumn)
+	}
+
+	tx, err := dbPool.Begin()
+	kkpanic.P(err)
+
+	addDBColumn(tx, "city_info", "search_text", "text")
+
+	s := fmt.Sprintf(`CREATE OR REPLACE FUNCTION city_info_search_text() RETURNS trigger AS $$
+BEGIN
+	NEW.search_text := %s;
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;`, getSearchNormalize(fmt.Sprintf("concat_ws(' ',%s)", strings.Join(columns, ","))))
+
+	_, err = tx.Exec(s)
+	kkpanic.P(err)
+
+	_, err = tx.Exec("DROP TRIGGER IF EXISTS trigger_city_info_search_text ON city_info;")
+	kkpanic.P(err)
+
+	_, err = tx.Exec("CREATE TRIGGER trigger_city_info_search_text BEFORE INSERT OR UPDATE ON city_info FOR EACH ROW EXECUTE PROCEDURE city_info_search_text();")
+	kkpanic.P(err)
+
+	if searchTrigram {
+		_, err = tx.Exec("CREATE INDEX IF NOT EXISTS index_city_info_search_text ON city_info USING gin (search_text gin_trgm_ops);")
+		kkpanic.P(err)
+	}
+
+	// fill the cities recorded before
+	_, err = tx.Exec("UPDATE city_info SET search_text=NULL WHERE search_text IS NULL;")
+	kkpanic.P(err)
+
+	kkpanic.P(tx.Commit())
+}
+
+// searchCities to search the recorded cities by the prefix of any word, or by similarity if pg_trgm is available.
+// The cities matched by prefix are returned first.
+// Return city ids, names, addresses, error
+func searchCities(input, lang string, policy NamePolicy, limit int) ([]string, []string, []string, error) {
+	// every parameter must be referenced, the input is only used by the similarity
+	args := []interface{}{escapeLike(input)}
+	pattern := getSearchNormalize("$1::text")
+	prefix := fmt.Sprintf("(search_text LIKE %[1]s||'%%' OR search_text LIKE '%% '||%[1]s||'%%')", pattern)
+
+	condition := fmt.Sprintf("search_text LIKE '%%'||%s||'%%'", pattern)
+	order := "placeid"
+	if searchTrigram {
+		args = append(args, input)
+		text := getSearchNormalize("$2::text")
+		condition = fmt.Sprintf("(%s OR %s<%%search_text)", prefix, text)
+		order = fmt.Sprintf("word_similarity(%s,search_text) DESC,placeid", text)
+	}
+
+	args = append(args, limit)
+	condition = fmt.Sprintf("WHERE %s ORDER BY CASE WHEN %s THEN 0 ELSE 1 END,%s LIMIT $%d", condition, prefix, order, len(args))
+	return queryCities(lang, policy, condition, args...)
+}
+
+// snapshotBatchSize the count of rows sent in one COPY when importing a snapshot.
+const snapshotBatchSize = 1000
+
+// getTableLangs to get the languages which have name columns in table.
+func getTableLangs(table string) ([]string, error) {
+	rows, err := dbPool.Query("SELECT column_name FROM information_schema.columns WHERE table_name=$1 AND column_name ~ '^name_[a-z]{2}$' ORDER BY column_name", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var langs []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return langs, err
+		}
+		langs = append(langs, strings.TrimPrefix(column, "name_"))
+	}
+	return langs, rows.Err()
+}
+
+// nullEmptyString to get nil for an empty string, so it won't overwrite the recorded value when importing.
+func nullEmptyString(s string) interface{} {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// nullFloat to get nil for a missing float.
+func nullFloat(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+// exportCities to pass every city in all the languages to fn.
+func exportCities(fn func(CityRecord) error) error {
+	langs, err := getTableLangs("city_info")
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"placeid", "country_id", "lat", "lng", "timezone", "name_type"}
+	for _, one := range langs {
+		nameColumn, addressColumn := getCityColumnNames(one)
+		columns = append(columns, nameColumn, getCityLongNameColumn(one), addressColumn)
+	}
+
+	rows, err := dbPool.Query(fmt.Sprintf("SELECT %s FROM city_info ORDER BY placeid", strings.Join(columns, ",")))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var placeID, countryID, timezone, nameType pgx.NullString
+		var lat, lng pgx.NullFloat64
+		names := make([]pgx.NullString, len(langs)*3)
+
+		dest := []interface{}{&placeID, &countryID, &lat, &lng, &timezone, &nameType}
+		for i := range names {
+			dest = append(dest, &names[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		city := CityRecord{
+			PlaceID:   placeID.String,
+			CountryID: countryID.String,
+			Timezone:  timezone.String,
+			NameType:  nameType.String,
+			Names:     make(map[string]CityName),
+		}
+		if lat.Valid && lng.Valid {
+			city.Lat, city.Lng = &lat.Float64, &lng.Float64
+		}
+
+		for i, one := range langs {
+			name := CityName{Name: names[i*3].String, LongName: names[i*3+1].String, Address: names[i*3+2].String}
+			if name != (CityName{}) {
+				city.Names[one] = name
+			}
+		}
+
+		if err := fn(city); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportCountries to pass every country having names from Google to fn.
+func exportCountries(fn func(CountryRecord) error) error {
+	langs, err := getTableLangs("country_info")
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"id"}
+	for _, one := range langs {
+		columns = append(columns, getCountryColumnName(one), getCountryShortNameColumn(one))
+	}
+
+	rows, err := dbPool.Query(fmt.Sprintf("SELECT %s FROM country_info ORDER BY id", strings.Join(columns, ",")))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		names := make([]pgx.NullString, len(langs)*2)
+
+		dest := []interface{}{&id}
+		for i := range names {
+			dest = append(dest, &names[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		country := CountryRecord{ID: id, Names: make(map[string]CountryName)}
+		for i, one := range langs {
+			name := CountryName{Name: names[i*2].String, ShortName: names[i*2+1].String}
+			if name != (CountryName{}) {
+				country.Names[one] = name
+			}
+		}
+
+		// the countries only seeded by Use have nothing to export
+		if len(country.Names) == 0 {
+			continue
+		}
+
+		if err := fn(country); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// importSnapshot to upsert the rows passed by read into table in one transaction.
+// The rows are copied into a temporary table first, then merged with the key column,
+// a NULL value keeps the recorded one.
+// Return the count of the rows, error.
+func importSnapshot(table, key string, columns []string, read func(add func([]interface{}) error) error) (int, error) {
+	tx, err := dbPool.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	tempTable := table + "_import"
+	if _, err := tx.Exec(fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s) ON COMMIT DROP", tempTable, table)); err != nil {
+		return 0, err
+	}
+
+	var count int
+	var batch [][]interface{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := tx.CopyTo(tempTable, columns, pgx.CopyToRows(batch))
+		batch = nil
+		return err
+	}
+
+	err = read(func(row []interface{}) error {
+		count++
+		if batch = append(batch, row); len(batch) >= snapshotBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	var updates []string
+	for _, one := range columns {
+		if one != key {
+			updates = append(updates, fmt.Sprintf("%[1]s=COALESCE(EXCLUDED.%[1]s,%[2]s.%[1]s)", one, table))
+		}
+	}
+
+	s := fmt.Sprintf("INSERT INTO %[1]s(%[2]s) SELECT DISTINCT ON (%[3]s) %[2]s FROM %[4]s ORDER BY %[3]s ON CONFLICT (%[3]s) DO UPDATE SET %[5]s",
+		table, strings.Join(columns, ","), key, tempTable, strings.Join(updates, ","))
+	if _, err := tx.Exec(s); err != nil {
+		return 0, err
+	}
+	return count, tx.Commit()
+}
+
+// importCities to import the cities passed by read.
+// Return the count of the cities, error.
+func importCities(read func(fn func(CityRecord) error) error) (int, error) {
+	langs, err := getTableLangs("city_info")
+	if err != nil {
+		return 0, err
+	}
+
+	columns := []string{"placeid", "country_id", "lat", "lng", "timezone", "name_type"}
+	for _, one := range langs {
+		nameColumn, addressColumn := getCityColumnNames(one)
+		columns = append(columns, nameColumn, getCityLongNameColumn(one), addressColumn)
+	}
+
+	return importSnapshot("city_info", "placeid", columns, func(add func([]interface{}) error) error {
+		return read(func(city CityRecord) error {
+			if len(city.PlaceID) == 0 {
+				return ErrFormat
+			}
+
+			row := []interface{}{city.PlaceID, nullEmptyString(city.CountryID), nullFloat(city.Lat), nullFloat(city.Lng),
+				nullEmptyString(city.Timezone), nullEmptyString(city.NameType)}
+			for _, one := range langs {
+				name := city.Names[one]
+				row = append(row, nullEmptyString(name.Name), nullEmptyString(name.LongName), nullEmptyString(name.Address))
+			}
+			return add(row)
+		})
+	})
+}
+
+// importCountries to import the countries passed by read.
+// Return the count of the countries, error.
+func importCountries(read func(fn func(CountryRecord) error) error) (int, error) {
+	langs, err := getTableLangs("country_info")
+	if err != nil {
+		return 0, err
+	}
+
+	columns := []string{"id"}
+	for _, one := range langs {
+		columns = append(columns, getCountryColumnName(one), getCountryShortNameColumn(one))
+	}
+
+	return importSnapshot("country_info", "id", columns, func(add func([]interface{}) error) error {
+		return read(func(country CountryRecord) error {
+			if err := checkCountryID(country.ID); err != nil {
+				return err
+			}
+
+			row := []interface{}{strings.ToUpper(country.ID)}
+			for _, one := range langs {
+				name := country.Names[one]
+				row = append(row, nullEmptyString(name.Name), nullEmptyString(name.ShortName))
+			}
+			return add(row)
+		})
+	})
+}
@@ -1,6 +1,14 @@
 package kkcity
 
-import "github.com/stretchr/testify/suite"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/stretchr/testify/suite"
+)
 
 type dbHandleSuite struct {
 	suite.Suite
@@ -14,6 +22,18 @@ func (suite *dbHandleSuite) TearDownSuite() {
 	_, err = dbPool.Exec("DROP TABLE city_info;")
 	suite.NoError(err, "city_info should be able to be dropped.")
 
+	_, err = dbPool.Exec("DROP TABLE region_info;")
+	suite.NoError(err, "region_info should be able to be dropped.")
+
+	_, err = dbPool.Exec("DROP TABLE city_placeid_map;")
+	suite.NoError(err, "city_placeid_map should be able to be dropped.")
+
+	_, err = dbPool.Exec("DROP TABLE city_cell_map;")
+	suite.NoError(err, "city_cell_map should be able to be dropped.")
+
+	_, err = dbPool.Exec("DROP FUNCTION city_info_search_text();")
+	suite.NoError(err, "city_info_search_text should be able to be dropped.")
+
 	dbPool.Close()
 }
 
@@ -61,8 +81,28 @@ func (suite *dbHandleSuite) TestCityInfo() {
 	err = updateCityInfo(pid1, cityName, cityAddress, lang)
 	suite.NoError(err, "Should be able to update city info.")
 
+	var longName string
+	_, longName, _, err = getCityInfo(pid1, lang, NameLong)
+	suite.NoError(err, "Should be able to get.")
+	suite.Equal(cityName, longName, "Long name should fall back to the name.")
+
+	err = updateCityLongName(pid1, "Xiamen City", lang)
+	suite.NoError(err, "Should be able to update city long name.")
+
+	_, longName, _, err = getCityInfo(pid1, lang, NameLong)
+	suite.NoError(err, "Should be able to get.")
+	suite.Equal("Xiamen City", longName, "Long name is wrong.")
+
+	err = updateCityNameType(pid1, "locality")
+	suite.NoError(err, "Should be able to update city name type.")
+
+	var nameType string
+	nameType, err = getCityNameType(pid1)
+	suite.NoError(err, "Should be able to get city name type.")
+	suite.Equal("locality", nameType, "Name type is wrong.")
+
 	// get city information
-	existed, resultName, resultAddress, err := getCityInfo(pid1, lang)
+	existed, resultName, resultAddress, err := getCityInfo(pid1, lang, NameDefault)
 	suite.True(existed, "The result should be existed.")
 	suite.NoError(err, "Should be able to get.")
 	suite.EqualValues(cityName, resultName, "The name should be equal")
@@ -73,7 +113,7 @@ func (suite *dbHandleSuite) TestCityInfo() {
 	suite.NoError(err, "Shoule be able to get language.")
 
 	// get not set language information
-	existed, resultName, resultAddress, err = getCityInfo(pid1, noLang)
+	existed, resultName, resultAddress, err = getCityInfo(pid1, noLang, NameDefault)
 	suite.True(existed, "The result should be existed.")
 	suite.NoError(err, "Should be able to get.")
 	suite.EqualValues("", resultName, "The name should be empty")
@@ -81,7 +121,7 @@ func (suite *dbHandleSuite) TestCityInfo() {
 
 	// check not existed city
 	noPlace := "placeid2"
-	existed, _, _, err = getCityInfo(noPlace, lang)
+	existed, _, _, err = getCityInfo(noPlace, lang, NameDefault)
 	suite.False(existed, "The place should be not existed.")
 	suite.NoError(err, "Should be able to get.")
 
@@ -91,7 +131,7 @@ func (suite *dbHandleSuite) TestCityInfo() {
 
 	// get all the cities in one country.
 	var pids, names, addresses []string
-	pids, names, addresses, err = getCountryCities(countryID, lang)
+	pids, names, addresses, err = getCountryCities(countryID, lang, NameDefault)
 	suite.NoError(err, "Shoule be able to get cities.")
 	suite.EqualValues(2, len(pids), "Should have 2 result.")
 	suite.EqualValues(2, len(names), "Should have 2 result.")
@@ -115,12 +155,13 @@ func (suite *dbHandleSuite) TestCityInfo() {
 }
 
 func (suite *dbHandleSuite) TestCountryInfo() {
+	// user-assigned codes which are not seeded
 	id1 := "EN"
-	id2 := "CN"
+	id2 := "XA"
 	name1 := "English"
 	name2 := "Chinese"
 	name2CN := "中国"
-	id2Lower := "cn"
+	id2Lower := "xa"
 
 	badID := "123"
 
@@ -148,17 +189,30 @@ func (suite *dbHandleSuite) TestCountryInfo() {
 	err = updateCountryInfo(id2, name2CN, lang1)
 	suite.NoError(err, "Shoule have no error.")
 
-	var ids, names []string
-	ids, names, err = getCountries(lang1)
+	err = updateCountryShortName(id2, id2, lang1)
 	suite.NoError(err, "Shoule have no error.")
 
-	suite.EqualValues(2, len(ids), "Shoule have 2 result.")
-	suite.EqualValues(2, len(names), "Shoule have 2 result.")
+	var ids, names []string
+	ids, names, err = getCountries(lang1, NameShort)
+	suite.NoError(err, "Shoule have no error.")
 	for i, one := range ids {
-		if one != id1 && one != id2 {
-			suite.Fail("id is wrong.")
+		if one == id2 {
+			suite.Equal(id2, names[i], "Short name is wrong.")
 		}
 
+		// the seeded countries without short names fall back to their names
+		if one == "CN" {
+			suite.NotEmpty(names[i], "Short name should fall back.")
+		}
+	}
+
+	ids, names, err = getCountries(lang1, NameDefault)
+	suite.NoError(err, "Shoule have no error.")
+
+	// the seeded countries are included
+	suite.EqualValues(len(countryData)+2, len(ids), "Shoule have all the countries.")
+	suite.EqualValues(len(countryData)+2, len(names), "Shoule have all the countries.")
+	for i, one := range ids {
 		if one == id1 {
 			suite.Equal("", names[i], "Name should be empty.")
 		}
@@ -181,3 +235,503 @@ func (suite *dbHandleSuite) TestCountryInfo() {
 	suite.Equal(name2CN, name, "Name is wrong.")
 	suite.NoError(err, "Should be able to get country.")
 }
+
+func (suite *dbHandleSuite) TestCountryMeta() {
+	lang0, err := getLanguage(0)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	existed, country, err := getCountry("cn", lang0, NameDefault)
+	suite.NoError(err, "Should be able to get country.")
+	suite.True(existed, "Country should be seeded.")
+	suite.Equal("CN", country.ID, "ID is wrong.")
+	suite.Equal("CHN", country.Alpha3, "Alpha3 is wrong.")
+	suite.Equal("156", country.Numeric, "Numeric is wrong.")
+	suite.Equal("China", country.Name, "CLDR name should be seeded.")
+	suite.Equal("Asia", country.Continent, "Continent is wrong.")
+	suite.Equal("CNY", country.Currency, "Currency is wrong.")
+	suite.Equal("+86", country.CallingCode, "Calling code is wrong.")
+	suite.Equal("Beijing", country.Capital, "Capital is wrong.")
+
+	lang1, err := getLanguage(1)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	// the CLDR name is used only when there is no name from Google
+	_, country, err = getCountry("FR", lang1, NameDefault)
+	suite.NoError(err, "Should be able to get country.")
+	suite.Equal("法国", country.Name, "CLDR name should be seeded.")
+
+	err = updateCountryInfo("FR", "法兰西", lang1)
+	suite.NoError(err, "Shoule have no error.")
+
+	_, country, err = getCountry("FR", lang1, NameDefault)
+	suite.NoError(err, "Should be able to get country.")
+	suite.Equal("法兰西", country.Name, "Name from Google should be prior.")
+
+	var ids, names []string
+	ids, names, err = getCountries(lang1, NameDefault)
+	suite.NoError(err, "Should be able to get countries.")
+	for i, one := range ids {
+		if one == "DE" {
+			suite.Equal("德国", names[i], "CLDR name should be returned.")
+		}
+	}
+
+	existed, _, err = getCountry("ZZ", lang0, NameDefault)
+	suite.NoError(err, "Should be able to get country.")
+	suite.False(existed, "Country should not be existed.")
+
+	_, _, err = getCountry("123", lang0, NameDefault)
+	suite.Equal(ErrCountryID, err, "Should have bad country id error.")
+
+	var countries []Country
+	countries, err = getCountriesInfo(lang0, NameDefault)
+	suite.NoError(err, "Should be able to get countries.")
+	suite.True(len(countries) >= len(countryData), "All the countries should be seeded.")
+}
+
+func (suite *dbHandleSuite) TestCityPlaceIDMapping() {
+	oldID := "oldplaceid"
+	newID := "newplaceid"
+	newerID := "newerplaceid"
+	countryID := "JP"
+
+	lang, err := getLanguage(0)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	err = addCityInfo(oldID, countryID, "Tokyo", "Tokyo, Japan", lang)
+	suite.NoError(err, "Should be able to add city info.")
+
+	err = updateCityLocation(oldID, 35.68, 139.69)
+	suite.NoError(err, "Should be able to update city location.")
+
+	located, lat, lng, err := getCityLocation(oldID)
+	suite.NoError(err, "Should be able to get city location.")
+	suite.True(located, "City should be located.")
+	suite.EqualValues(35.68, lat, "Latitude is wrong.")
+	suite.EqualValues(139.69, lng, "Longitude is wrong.")
+
+	var existed bool
+	var zone string
+	existed, zone, err = getCityTimezone(oldID)
+	suite.NoError(err, "Should be able to get city timezone.")
+	suite.True(existed, "City should be existed.")
+	suite.Equal("", zone, "Timezone should not be recorded.")
+
+	err = updateCityTimezone(oldID, "Asia/Tokyo")
+	suite.NoError(err, "Should be able to update city timezone.")
+
+	_, zone, err = getCityTimezone(oldID)
+	suite.NoError(err, "Should be able to get city timezone.")
+	suite.Equal("Asia/Tokyo", zone, "Timezone is wrong.")
+
+	located, _, _, err = getCityLocation(newID)
+	suite.NoError(err, "Should be able to get city location.")
+	suite.False(located, "City should not be located.")
+
+	var current string
+	current, err = getCurrentPlaceID(oldID)
+	suite.NoError(err, "Should be able to get current placeid.")
+	suite.Equal(oldID, current, "Placeid should not be changed.")
+
+	err = replaceCityPlaceID(oldID, newID)
+	suite.NoError(err, "Should be able to replace placeid.")
+
+	current, err = getCurrentPlaceID(oldID)
+	suite.NoError(err, "Should be able to get current placeid.")
+	suite.Equal(newID, current, "Placeid should be replaced.")
+
+	var name string
+	existed, name, _, err = getCityInfo(newID, lang, NameDefault)
+	suite.NoError(err, "Should be able to get.")
+	suite.True(existed, "City should be moved to the new placeid.")
+	suite.Equal("Tokyo", name, "Name is wrong.")
+
+	existed, _, _, err = getCityInfo(oldID, lang, NameDefault)
+	suite.NoError(err, "Should be able to get.")
+	suite.False(existed, "City should not be existed with the old placeid.")
+
+	// the newer city is already recorded
+	err = addCityInfo(newerID, countryID, "Tokyo", "Tokyo, Japan", lang)
+	suite.NoError(err, "Should be able to add city info.")
+
+	err = replaceCityPlaceID(newID, newerID)
+	suite.NoError(err, "Should be able to replace placeid.")
+
+	current, err = getCurrentPlaceID(oldID)
+	suite.NoError(err, "Should be able to get current placeid.")
+	suite.Equal(newerID, current, "Placeid should follow the newer one.")
+
+	existed, _, _, err = getCityInfo(newID, lang, NameDefault)
+	suite.NoError(err, "Should be able to get.")
+	suite.False(existed, "Redundant city should be removed.")
+
+	// delete the city
+	existed, err = deleteCityInfo(newerID)
+	suite.NoError(err, "Should be able to delete.")
+	suite.True(existed, "City should be deleted.")
+
+	existed, err = deleteCityInfo(newerID)
+	suite.NoError(err, "Should be able to delete.")
+	suite.False(existed, "City should be already deleted.")
+}
+
+func (suite *dbHandleSuite) TestStats() {
+	stats, err := getStats(testLangs)
+	suite.NoError(err, "Should be able to get stats.")
+	suite.True(stats.Countries >= int64(len(countryData)), "Countries should be seeded.")
+	suite.Equal(len(testLangs), len(stats.CitiesWithName), "Should count each language.")
+	suite.True(stats.Cities >= stats.CitiesWithName[testLangs[0]], "Cities with name should be less.")
+}
+
+func (suite *dbHandleSuite) TestRegionInfo() {
+	countryID := "US"
+	stateName := "California"
+	countyName := "Santa Clara County"
+	pid := "regionplaceid"
+
+	var lang0, lang1 string
+	var err error
+
+	lang0, err = getLanguage(0)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	lang1, err = getLanguage(1)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	var stateID, countyID int64
+	stateID, err = addRegion(countryID, 0, 1, stateName, lang0)
+	suite.NoError(err, "Should be able to add region.")
+
+	countyID, err = addRegion(countryID, stateID, 2, countyName, lang0)
+	suite.NoError(err, "Should be able to add region.")
+
+	var existed bool
+	var id int64
+	existed, id, err = findRegion(countryID, 0, 1, stateName, lang0)
+	suite.NoError(err, "Should be able to find region.")
+	suite.True(existed, "Region should be existed.")
+	suite.Equal(stateID, id, "Region id is wrong.")
+
+	existed, _, err = findRegion(countryID, 0, 2, countyName, lang0)
+	suite.NoError(err, "Should be able to find region.")
+	suite.False(existed, "Region without parent should not be existed.")
+
+	err = updateRegionInfo(stateID, "加利福尼亚州", lang1)
+	suite.NoError(err, "Should be able to update region.")
+
+	var level int
+	var parentID int64
+	var name string
+	existed, level, parentID, name, err = getRegionInfo(countyID, lang0)
+	suite.NoError(err, "Should be able to get region.")
+	suite.True(existed, "Region should be existed.")
+	suite.Equal(2, level, "Level is wrong.")
+	suite.Equal(stateID, parentID, "Parent is wrong.")
+	suite.Equal(countyName, name, "Name is wrong.")
+
+	var ids []int64
+	var names []string
+	ids, names, err = getCountryRegions(countryID, lang1)
+	suite.NoError(err, "Should be able to get regions.")
+	suite.Equal([]int64{stateID}, ids, "Region ids are wrong.")
+	suite.Equal([]string{"加利福尼亚州"}, names, "Region names are wrong.")
+
+	ids, names, err = getSubregions(stateID, lang0)
+	suite.NoError(err, "Should be able to get regions.")
+	suite.Equal([]int64{countyID}, ids, "Region ids are wrong.")
+	suite.Equal([]string{countyName}, names, "Region names are wrong.")
+
+	err = addCityInfo(pid, countryID, "San Jose", "San Jose, CA, USA", lang0)
+	suite.NoError(err, "Should be able to add city info.")
+
+	err = updateCityRegion(pid, countyID)
+	suite.NoError(err, "Should be able to update city region.")
+
+	id, err = getCityRegionID(pid)
+	suite.NoError(err, "Should be able to get city region.")
+	suite.Equal(countyID, id, "City region is wrong.")
+
+	var pids []string
+	pids, _, _, err = getRegionCities(stateID, lang0, NameDefault)
+	suite.NoError(err, "Should be able to get region cities.")
+	suite.Equal([]string{pid}, pids, "Cities of the parent region are wrong.")
+
+	pids, _, _, err = getRegionCities(countyID, lang0, NameDefault)
+	suite.NoError(err, "Should be able to get region cities.")
+	suite.Equal([]string{pid}, pids, "Cities of the region are wrong.")
+
+	// the cities of one region first recorded in different languages share the region
+	var requests int32
+	useTestGoogle(suite.T(), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		suite.Equal(lang0, r.URL.Query().Get("language"), "City should be requested in the language of the region.")
+		fmt.Fprint(w, `{"status":"OK","result":{"address_components":[
+			{"long_name":"Fuzhou","short_name":"Fuzhou","types":["locality","political"]},
+			{"long_name":"Fujian","short_name":"Fujian","types":["administrative_area_level_1","political"]},
+			{"long_name":"China","short_name":"CN","types":["country","political"]}]}}`)
+	})
+
+	cities := []string{"regionplaceid1", "regionplaceid2", "regionplaceid3"}
+	cityLangs := []string{lang0, lang1, lang1}
+	regionNames := []string{"Fujian", "福建省", "福建省"}
+	for i, one := range cities {
+		err = addCityInfo(one, "CN", one, "", cityLangs[i])
+		suite.NoError(err, "Should be able to add city info.")
+
+		err = handleRegionInfo(context.Background(), one, placeInfo{Country: "CN", RegionNames: [regionLevels]string{regionNames[i]}}, cityLangs[i])
+		suite.NoError(err, "Should be able to handle region info.")
+	}
+	suite.Equal(int32(1), atomic.LoadInt32(&requests), "City should be requested once to match the region.")
+
+	ids, names, err = getCountryRegions("CN", lang1)
+	suite.NoError(err, "Should be able to get regions.")
+	suite.Equal(1, len(ids), "Region should not be duplicated.")
+	suite.Equal([]string{"福建省"}, names, "Region name should be filled.")
+
+	for _, one := range cities {
+		id, err = getCityRegionID(one)
+		suite.NoError(err, "Should be able to get city region.")
+		suite.Equal(ids[0], id, "City region is wrong.")
+
+		_, err = deleteCityInfo(one)
+		suite.NoError(err, "Should be able to delete city.")
+	}
+}
+
+func (suite *dbHandleSuite) TestSnapshot() {
+	lang := testLangs[0]
+	records := testCityRecords()
+	records[0].PlaceID = "snapshot1"
+	records[1].PlaceID = "snapshot2"
+
+	read := func(fn func(CityRecord) error) error {
+		for _, one := range records {
+			if err := fn(one); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	count, err := importCities(read)
+	suite.NoError(err, "Should be able to import cities.")
+	suite.Equal(2, count, "Imported count is wrong.")
+
+	// importing again keeps the names not in the snapshot
+	records[0].Names = nil
+	count, err = importCities(read)
+	suite.NoError(err, "Should be able to import cities again.")
+	suite.Equal(2, count, "Imported count is wrong.")
+
+	existed, name, address, err := getCityInfo("snapshot1", lang, NameDefault)
+	suite.NoError(err, "Should be able to get city.")
+	suite.True(existed, "City should be imported.")
+	suite.Equal(testCityRecords()[0].Names[lang].Name, name, "Name should be kept.")
+	suite.Equal(testCityRecords()[0].Names[lang].Address, address, "Address should be kept.")
+
+	var cities []CityRecord
+	err = exportCities(func(city CityRecord) error {
+		if strings.HasPrefix(city.PlaceID, "snapshot") {
+			cities = append(cities, city)
+		}
+		return nil
+	})
+	suite.NoError(err, "Should be able to export cities.")
+	suite.Equal(2, len(cities), "Exported cities are wrong.")
+	suite.Equal("Asia/Shanghai", cities[0].Timezone, "Timezone is wrong.")
+	suite.Equal(testCityRecords()[0].Names[lang], cities[0].Names[lang], "Names are wrong.")
+
+	for _, one := range []string{"snapshot1", "snapshot2"} {
+		_, err = deleteCityInfo(one)
+		suite.NoError(err, "Should be able to delete city.")
+	}
+
+	count, err = importCountries(func(fn func(CountryRecord) error) error {
+		return fn(CountryRecord{ID: "xb", Names: map[string]CountryName{lang: {Name: "Snapshot Land"}}})
+	})
+	suite.NoError(err, "Should be able to import countries.")
+	suite.Equal(1, count, "Imported count is wrong.")
+
+	var countries []CountryRecord
+	err = exportCountries(func(country CountryRecord) error {
+		if country.ID == "XB" {
+			countries = append(countries, country)
+		}
+		return nil
+	})
+	suite.NoError(err, "Should be able to export countries.")
+	suite.Equal([]CountryRecord{{ID: "XB", Names: map[string]CountryName{lang: {Name: "Snapshot Land"}}}}, countries, "Exported countries are wrong.")
+
+	_, err = importCountries(func(fn func(CountryRecord) error) error {
+		return fn(CountryRecord{ID: "XYZ"})
+	})
+	suite.Equal(ErrCountryID, err, "Country ID should be wrong.")
+
+	_, err = dbPool.Exec("DELETE FROM country_info WHERE id=$1", "XB")
+	suite.NoError(err, "Should be able to delete country.")
+}
+
+func (suite *dbHandleSuite) TestListing() {
+	lang := testLangs[0]
+	countryID := "XC"
+	names := []string{"bravo", "Alpha", "charlie", ""}
+
+	for i, one := range names {
+		err := addCityInfo(fmt.Sprintf("listing%d", i), countryID, one, "", lang)
+		suite.NoError(err, "Should be able to add city info.")
+	}
+
+	var ids []string
+	opts := ListOptions{Limit: 2, Sort: SortByName, NamedOnly: true}
+	for {
+		page, err := listCities(lang, opts, "country_id=$1", countryID)
+		suite.NoError(err, "Should be able to list cities.")
+		suite.True(len(page.PlaceIDs) <= opts.Limit, "Page is over the limit.")
+		ids = append(ids, page.PlaceIDs...)
+
+		if len(page.NextCursor) == 0 {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+	suite.Equal(3, len(ids), "Cities without name should be filtered.")
+	suite.Contains(ids, "listing1", "Named city should be listed.")
+	suite.NotContains(ids, "listing3", "City without name should be filtered.")
+
+	page, err := listCities(lang, ListOptions{Descending: true}, "country_id=$1", countryID)
+	suite.NoError(err, "Should be able to list cities.")
+	suite.Equal([]string{"listing3", "listing2", "listing1", "listing0"}, page.PlaceIDs, "Cities should be sorted by placeid.")
+	suite.Empty(page.NextCursor, "It should be the last page.")
+
+	_, err = listCities(lang, ListOptions{Cursor: page.NextCursor + "wrong"}, "country_id=$1", countryID)
+	suite.Equal(ErrCursor, err, "Cursor should be wrong.")
+
+	countries, err := listCountries(lang, ListOptions{Limit: 3})
+	suite.NoError(err, "Should be able to list countries.")
+	suite.Equal(3, len(countries.Countries), "Countries should be limited.")
+	suite.NotEmpty(countries.NextCursor, "There should be next page.")
+
+	next, err := listCountries(lang, ListOptions{Limit: 3, Cursor: countries.NextCursor})
+	suite.NoError(err, "Should be able to list countries.")
+	suite.True(countries.Countries[2].ID < next.Countries[0].ID, "Next page should follow.")
+
+	for i := range names {
+		_, err = deleteCityInfo(fmt.Sprintf("listing%d", i))
+		suite.NoError(err, "Should be able to delete city.")
+	}
+}
+
+func (suite *dbHandleSuite) TestSearch() {
+	lang := testLangs[0]
+
+	err := addCityInfo("search1", "FR", "Besançon", "Besançon, France", lang)
+	suite.NoError(err, "Should be able to add city info.")
+
+	err = addCityInfo("search2", "FR", "Saint-Étienne", "Saint-Étienne, France", lang)
+	suite.NoError(err, "Should be able to add city info.")
+
+	ids, names, _, err := searchCities("besan", lang, NameDefault, 5)
+	suite.NoError(err, "Should be able to search cities.")
+	suite.Equal([]string{"search1"}, ids, "Prefix should match.")
+	suite.Equal([]string{"Besançon"}, names, "Name is wrong.")
+
+	if searchUnaccent {
+		ids, _, _, err = searchCities("ETIENNE", lang, NameDefault, 5)
+		suite.NoError(err, "Should be able to search cities.")
+		suite.Equal([]string{"search2"}, ids, "Search should be case and accent insensitive.")
+	}
+
+	ids, _, _, err = searchCities("fran", lang, NameDefault, 1)
+	suite.NoError(err, "Should be able to search cities.")
+	suite.Equal(1, len(ids), "Search should be limited.")
+
+	ids, _, _, err = searchCities("%", lang, NameDefault, 5)
+	suite.NoError(err, "Should be able to search cities.")
+	suite.Empty(ids, "Wildcard should be escaped.")
+
+	// without pg_trgm the cities are still searched by substring
+	trigram := searchTrigram
+	searchTrigram = false
+
+	ids, names, _, err = searchCities("besan", lang, NameDefault, 5)
+	suite.NoError(err, "Should be able to search cities without trigram.")
+	suite.Equal([]string{"search1"}, ids, "Prefix should match without trigram.")
+	suite.Equal([]string{"Besançon"}, names, "Name is wrong.")
+
+	ids, _, _, err = searchCities("fran", lang, NameDefault, 1)
+	suite.NoError(err, "Should be able to search cities without trigram.")
+	suite.Equal(1, len(ids), "Search should be limited without trigram.")
+
+	searchTrigram = trigram
+
+	for _, one := range []string{"search1", "search2"} {
+		_, err = deleteCityInfo(one)
+		suite.NoError(err, "Should be able to delete city.")
+	}
+}
+
+func (suite *dbHandleSuite) TestCellPlaceID() {
+	cell := getCellKey(24.47, 118.08)
+
+	existed, _, err := getCellPlaceID(cell)
+	suite.NoError(err, "Should be able to get cell.")
+	suite.False(existed, "Cell should not be existed.")
+
+	suite.NoError(setCellPlaceID(cell, "placeid1"), "Should be able to set cell.")
+	suite.NoError(setCellPlaceID(cell, "placeid2"), "Should be able to set cell again.")
+
+	existed, placeid, err := getCellPlaceID(cell)
+	suite.NoError(err, "Should be able to get cell.")
+	suite.True(existed, "Cell should be existed.")
+	suite.Equal("placeid2", placeid, "Placeid of the cell is wrong.")
+}
+
+// timezoneFunc to resolve timezone with a function in tests.
+type timezoneFunc func(lat, lng float64) (string, error)
+
+func (f timezoneFunc) Timezone(lat, lng float64) (string, error) {
+	return f(lat, lng)
+}
+
+func (suite *dbHandleSuite) TestRecordPlaceInfoTimezone() {
+	defer SetTimezoneProvider(timezoneProvider)
+
+	lang, err := getLanguage(0)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	// the city is recorded if the location has no timezone
+	SetTimezoneProvider(timezoneFunc(func(lat, lng float64) (string, error) { return "", ErrNoTimezone }))
+	info := placeInfo{PlaceID: "seaplaceid", Country: "PT", CountryName: "Portugal", Name: "Corvo", Address: "Corvo, Portugal", Lat: 39.7, Lng: -31.1}
+	_, name, _, err := recordPlaceInfo(context.Background(), "seaplaceid", info, lang, NameDefault, false)
+	suite.NoError(err, "Should be able to record the city without timezone.")
+	suite.Equal("Corvo", name, "City name is wrong.")
+
+	// the other errors are returned
+	SetTimezoneProvider(timezoneFunc(func(lat, lng float64) (string, error) { return "", ErrLimitation }))
+	info = placeInfo{PlaceID: "limitplaceid", Country: "PT", CountryName: "Portugal", Name: "Lisbon", Address: "Lisbon, Portugal", Lat: 38.7, Lng: -9.1}
+	_, _, _, err = recordPlaceInfo(context.Background(), "limitplaceid", info, lang, NameDefault, false)
+	suite.Equal(ErrLimitation, err, "Timezone error should be returned.")
+}
+
+func (suite *dbHandleSuite) TestFillCityTimezones() {
+	lang, err := getLanguage(0)
+	suite.NoError(err, "Shoule be able to get language.")
+
+	suite.NoError(addCityInfo("zoneplaceid1", "NZ", "Auckland", "Auckland, New Zealand", lang), "Should be able to add city info.")
+	suite.NoError(updateCityTimezone("zoneplaceid1", "Pacific/Auckland"), "Should be able to update city timezone.")
+	suite.NoError(addCityInfo("zoneplaceid2", "NZ", "Wellington", "Wellington, New Zealand", lang), "Should be able to add city info.")
+
+	results := []CityResult{
+		{PlaceID: "zoneplaceid1"},
+		{Rank: 1, PlaceID: "zoneplaceid2"},
+		{Rank: 2, PlaceID: "zoneplaceid3", Err: ErrNotFound},
+	}
+	suite.NoError(fillCityTimezones(results), "Should be able to fill timezones.")
+	suite.Equal("Pacific/Auckland", results[0].Timezone, "Timezone is wrong.")
+	suite.Equal("", results[1].Timezone, "Timezone should not be resolved.")
+	suite.Equal("", results[2].Timezone, "Failed city should have no timezone.")
+
+	result := withCityTimezone(CityResult{PlaceID: "zoneplaceid1"})
+	suite.NoError(result.Err, "Should be able to get timezone.")
+	suite.Equal("Pacific/Auckland", result.Timezone, "Timezone is wrong.")
+}
@@ -0,0 +1,92 @@
+package kkcity
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// enrichQueueSize the max count of the cities waiting to be recorded in background, the others are skipped.
+const enrichQueueSize = 100
+
+// enrichTask to define a city to be recorded in background.
+type enrichTask struct {
+	placeid string
+	lang    string
+	policy  NamePolicy
+}
+
+// key to get the key of the task, a city is recorded once for each language.
+func (t enrichTask) key() string {
+	return t.lang + ":" + t.placeid
+}
+
+// enricher to record the cities of the fast predictions in background with one worker.
+// The cities already queued or in flight are skipped, so the same city is requested once while typing.
+type enricher struct {
+	mu      sync.Mutex
+	pending map[string]bool
+	tasks   chan enrichTask
+	once    sync.Once
+
+	// resolve is called by the worker for each task.
+	resolve func(enrichTask) error
+}
+
+// newEnricher to create the enricher, the worker starts with the first task.
+func newEnricher(size int, resolve func(enrichTask) error) *enricher {
+	return &enricher{pending: make(map[string]bool), tasks: make(chan enrichTask, size), resolve: resolve}
+}
+
+// cityEnricher the enricher of the fast predictions.
+var cityEnricher = newEnricher(enrichQueueSize, func(task enrichTask) error {
+	_, _, _, err := handleCityInfo(context.Background(), task.placeid, task.lang, task.policy)
+	return err
+})
+
+// enrichErrorHandler to handle the errors of the cities recorded in background.
+var enrichErrorHandler = func(placeid string, err error) {
+	log.Printf("kkcity: can't record city %s in background: %v", placeid, err)
+}
+
+// SetEnrichErrorHandler to set the handler of the errors of the cities recorded in background
+// with SearchOptions.EnrichInBackground. The errors are logged by default, nil ignores them.
+func SetEnrichErrorHandler(handler func(placeid string, err error)) {
+	enrichErrorHandler = handler
+}
+
+// enqueue to queue the city to be recorded.
+// Return false if it is already queued or in flight, or the queue is full.
+func (e *enricher) enqueue(task enrichTask) bool {
+	e.once.Do(func() {
+		go e.run()
+	})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.pending[task.key()] {
+		return false
+	}
+
+	select {
+	case e.tasks <- task:
+		e.pending[task.key()] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// run to record the queued cities one by one.
+func (e *enricher) run() {
+	for task := range e.tasks {
+		if err := e.resolve(task); err != nil && enrichErrorHandler != nil {
+			enrichErrorHandler(task.placeid, err)
+		}
+
+		e.mu.Lock()
+		delete(e.pending, task.key())
+		e.mu.Unlock()
+	}
+}
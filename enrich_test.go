@@ -0,0 +1,41 @@
+package kkcity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnricher(t *testing.T) {
+	started := make(chan string)
+	release := make(chan struct{})
+	e := newEnricher(1, func(task enrichTask) error {
+		started <- task.placeid
+		<-release
+		return errors.New("failed")
+	})
+
+	var failed []string
+	handler := enrichErrorHandler
+	SetEnrichErrorHandler(func(placeid string, err error) {
+		failed = append(failed, placeid)
+	})
+	defer SetEnrichErrorHandler(handler)
+
+	assert.True(t, e.enqueue(enrichTask{placeid: "id1", lang: "en"}), "City should be queued.")
+	assert.Equal(t, "id1", <-started, "City should be in flight.")
+	assert.False(t, e.enqueue(enrichTask{placeid: "id1", lang: "en"}), "City in flight should be skipped.")
+	assert.True(t, e.enqueue(enrichTask{placeid: "id1", lang: "zh"}), "City of another language should be queued.")
+	assert.False(t, e.enqueue(enrichTask{placeid: "id1", lang: "zh"}), "Queued city should be skipped.")
+	assert.False(t, e.enqueue(enrichTask{placeid: "id2", lang: "en"}), "City should be skipped if the queue is full.")
+
+	release <- struct{}{}
+	assert.Equal(t, "id1", <-started, "Queued city should be in flight.")
+	release <- struct{}{}
+
+	// the worker is idle once the queue accepts the city again
+	assert.True(t, e.enqueue(enrichTask{placeid: "id1", lang: "en"}), "Recorded city should be queued again.")
+	assert.Equal(t, "id1", <-started, "City should be in flight.")
+	assert.Equal(t, []string{"id1", "id1"}, failed, "Errors should be handled.")
+}
@@ -1,11 +1,81 @@
 package kkcity
 
 import (
-	"sync"
+	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx"
 )
 
+// NamePolicy to define which form of the names is returned.
+// The lookups take an optional policy, NameDefault is used if it is omitted.
+type NamePolicy int
+
+const (
+	// NameDefault returns the short names of cities and the long names of countries.
+	NameDefault NamePolicy = iota
+
+	// NameShort returns the short names, such as "NYC" or "US".
+	NameShort
+
+	// NameLong returns the long names, such as "New York" or "United States".
+	NameLong
+)
+
+// getNamePolicy to get the policy from the optional arguments.
+func getNamePolicy(policy []NamePolicy) NamePolicy {
+	if len(policy) == 0 {
+		return NameDefault
+	}
+	return policy[0]
+}
+
+// Country to define the metadata of a country from ISO 3166.
+type Country struct {
+	// ID is the alpha-2 code, such as CN.
+	ID string `json:"id"`
+
+	// Alpha3 is the alpha-3 code, such as CHN.
+	Alpha3 string `json:"alpha3"`
+
+	// Numeric is the numeric code, such as 156.
+	Numeric string `json:"numeric"`
+
+	// Name is the name of the requested language.
+	Name string `json:"name"`
+
+	Continent string `json:"continent"`
+
+	// Currency is the ISO 4217 code, such as CNY.
+	Currency string `json:"currency"`
+
+	// CallingCode is such as +86, comma separated if the country has several.
+	CallingCode string `json:"calling_code"`
+
+	// Capital is the English name of the capital.
+	Capital string `json:"capital"`
+}
+
+// LatLng to define a location.
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Stats to define the statistics of the recorded data.
+type Stats struct {
+	Countries           int64 `json:"countries"`
+	CountriesWithCities int64 `json:"countries_with_cities"`
+	Cities              int64 `json:"cities"`
+	Regions             int64 `json:"regions"`
+	ReplacedPlaceIDs    int64 `json:"replaced_placeids"`
+
+	// CitiesWithName is the count of the cities having name of each language.
+	CitiesWithName map[string]int64 `json:"cities_with_name"`
+}
+
 // Use the pool to do further operations.
 // langs must follow ISO-639-1 (https://en.wikipedia.org/wiki/List_of_ISO_639-1_codes)
 func Use(langs []string, gKey string, pool *pgx.ConnPool) {
@@ -16,123 +86,588 @@ func Use(langs []string, gKey string, pool *pgx.ConnPool) {
 }
 
 // handleCityInfo to deal with city information with placeid.
-// Return city name, address, error
-func handleCityInfo(placeid, lang string) (string, string, error) {
+// Return current placeid, city name, address, error
+func handleCityInfo(ctx context.Context, placeid, lang string, policy NamePolicy) (string, string, string, error) {
 	var err error
 	var cityExist bool
 	var cityName, cityAddress string
 
-	cityExist, cityName, cityAddress, err = getCityInfo(placeid, lang)
+	if placeid, err = getCurrentPlaceID(placeid); err != nil {
+		return "", "", "", err
+	}
+
+	cityExist, cityName, cityAddress, err = getCityInfo(placeid, lang, policy)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 
 	cityLangExist := len(cityName) > 0
 	if !cityExist || !cityLangExist {
-		var country, countryName string
-		country, countryName, cityName, cityAddress, err = requestPlaceInfo(placeid, lang)
-		if err != nil {
-			return "", "", err
+		return fetchCityInfo(ctx, placeid, lang, policy, cityExist)
+	}
+	return placeid, cityName, cityAddress, nil
+}
+
+// handleGeocodedCity to deal with the city found by geocoding.
+// The geocoded information is in the language, it is recorded directly without requesting Place Details.
+// Return current placeid, city name, address, error
+func handleGeocodedCity(ctx context.Context, info placeInfo, lang string, policy NamePolicy) (string, string, string, error) {
+	placeid, err := getCurrentPlaceID(info.PlaceID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	cityExist, cityName, cityAddress, err := getCityInfo(placeid, lang, policy)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if cityExist && len(cityName) > 0 {
+		return placeid, cityName, cityAddress, nil
+	}
+
+	// the geocoded placeid may be replaced by the recorded one
+	info.PlaceID = placeid
+	return recordPlaceInfo(ctx, placeid, info, lang, policy, cityExist)
+}
+
+// fetchCityInfo to request city information from Google and record it.
+// If the placeid is obsolete, it will be re-resolved with the recorded location.
+// Return current placeid, city name, address, error
+func fetchCityInfo(ctx context.Context, placeid, lang string, policy NamePolicy, cityExist bool) (string, string, string, error) {
+	return fetchCityInfoWithSession(ctx, placeid, "", lang, policy, cityExist)
+}
+
+// fetchCityInfoWithSession to request city information from Google with the autocomplete session and record it.
+// The session is not used if sessionToken is empty.
+// Return current placeid, city name, address, error
+func fetchCityInfoWithSession(ctx context.Context, placeid, sessionToken, lang string, policy NamePolicy, cityExist bool) (string, string, string, error) {
+	info, err := requestPlaceInfo(ctx, placeid, lang, sessionToken)
+	if errors.Is(err, ErrNotFound) && cityExist {
+		info, err = refreshPlaceInfo(ctx, placeid, lang, err)
+	}
+	if err != nil {
+		return "", "", "", err
+	}
+	return recordPlaceInfo(ctx, placeid, info, lang, policy, cityExist)
+}
+
+// recordPlaceInfo to record the place information requested from Google.
+// placeid is the requested one, it is replaced if Google refreshed it.
+// If none of the city name types matched, return ErrNoPlace without recording, so an empty name is never cached.
+// Return current placeid, city name, address, error
+func recordPlaceInfo(ctx context.Context, placeid string, info placeInfo, lang string, policy NamePolicy, cityExist bool) (string, string, string, error) {
+	if len(info.Name) == 0 {
+		return "", "", "", ErrNoPlace
+	}
+
+	var err error
+	if info.PlaceID != placeid {
+		if err = replaceCityPlaceID(placeid, info.PlaceID); err != nil {
+			return "", "", "", err
 		}
 
-		var countryExist bool
-		var recordedCountryName string
-		countryExist, recordedCountryName, err = getCountryName(country, lang)
-		if err != nil {
-			return "", "", err
+		placeid = info.PlaceID
+		if cityExist, _, _, err = getCityInfo(placeid, lang, policy); err != nil {
+			return "", "", "", err
 		}
+	}
 
-		if !countryExist {
-			if err = addCountry(country, countryName, lang); err != nil {
-				return "", "", err
+	var countryExist bool
+	var recordedCountryName string
+	countryExist, recordedCountryName, err = getCountryName(info.Country, lang)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if !countryExist {
+		if err = addCountry(info.Country, info.CountryName, lang); err != nil {
+			return "", "", "", err
+		}
+	} else if len(recordedCountryName) == 0 {
+		if err = updateCountryInfo(info.Country, info.CountryName, lang); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if !countryExist || len(recordedCountryName) == 0 {
+		if err = updateCountryShortName(info.Country, info.Country, lang); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if !cityExist {
+		if err = addCityInfo(placeid, info.Country, info.Name, info.Address, lang); err != nil {
+			return "", "", "", err
+		}
+	} else {
+		if err = updateCityInfo(placeid, info.Name, info.Address, lang); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if err = updateCityLongName(placeid, info.LongName, lang); err != nil {
+		return "", "", "", err
+	}
+
+	if err = updateCityLocation(placeid, info.Lat, info.Lng); err != nil {
+		return "", "", "", err
+	}
+
+	// the city is still recorded if the location has no timezone, such as at sea
+	if _, err = handleCityTimezone(placeid, info.Lat, info.Lng); err != nil && !errors.Is(err, ErrNoTimezone) {
+		return "", "", "", err
+	}
+
+	if len(info.NameType) > 0 {
+		if err = updateCityNameType(placeid, info.NameType); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if err = handleRegionInfo(ctx, placeid, info, lang); err != nil {
+		return "", "", "", err
+	}
+
+	if policy == NameLong {
+		return placeid, info.LongName, info.Address, nil
+	}
+	return placeid, info.Name, info.Address, nil
+}
+
+// handleRegionInfo to record the regions of a city.
+// If the city already has regions, only the names of the language are filled.
+func handleRegionInfo(ctx context.Context, placeid string, info placeInfo, lang string) error {
+	regionID, err := getCityRegionID(placeid)
+	if err != nil {
+		return err
+	}
+
+	if regionID != 0 {
+		return fillRegionNames(regionID, info, lang)
+	}
+
+	others := make(map[string]placeInfo)
+	for i, name := range info.RegionNames {
+		if len(name) == 0 {
+			continue
+		}
+
+		var existed bool
+		var id int64
+		if existed, id, err = findRegion(info.Country, regionID, i+1, name, lang); err != nil {
+			return err
+		} else if !existed {
+			// the region may be recorded in the other languages only
+			if existed, id, err = matchRegion(ctx, placeid, info.Country, regionID, i+1, lang, others); err != nil {
+				return err
+			} else if existed {
+				err = fillRegionName(id, name, lang)
+			} else {
+				id, err = addRegion(info.Country, regionID, i+1, name, lang)
 			}
-		} else if len(recordedCountryName) == 0 {
-			if err = updateCountryInfo(country, countryName, lang); err != nil {
-				return "", "", err
+
+			if err != nil {
+				return err
 			}
 		}
+		regionID = id
+	}
 
-		if !cityExist {
-			if err = addCityInfo(placeid, country, cityName, cityAddress, lang); err != nil {
-				return "", "", err
+	if regionID == 0 {
+		return nil
+	}
+	return updateCityRegion(placeid, regionID)
+}
+
+// matchRegion to find the region not named in lang yet by its name in the other languages.
+// The city is requested in the languages which the unnamed regions are named in,
+// others keeps the place information requested for the levels of the city.
+// Return region existed, region id, error.
+func matchRegion(ctx context.Context, placeid, countryID string, parentID int64, level int, lang string, others map[string]placeInfo) (bool, int64, error) {
+	for _, one := range getAll() {
+		if one == lang {
+			continue
+		}
+
+		unnamed, err := hasUnnamedRegion(countryID, parentID, level, lang, one)
+		if err != nil {
+			return false, 0, err
+		} else if !unnamed {
+			continue
+		}
+
+		other, ok := others[one]
+		if !ok {
+			if other, err = requestPlaceInfo(ctx, placeid, one, ""); err != nil {
+				return false, 0, err
 			}
-		} else {
-			if err = updateCityInfo(placeid, cityName, cityAddress, lang); err != nil {
-				return "", "", err
+			others[one] = other
+		}
+
+		name := other.RegionNames[level-1]
+		if len(name) == 0 {
+			continue
+		}
+
+		existed, id, err := findRegion(countryID, parentID, level, name, one)
+		if err != nil || existed {
+			return existed, id, err
+		}
+	}
+	return false, 0, nil
+}
+
+// fillRegionName to fill the name of the language if the region has no name of it.
+func fillRegionName(id int64, name, lang string) error {
+	existed, _, _, recorded, err := getRegionInfo(id, lang)
+	if err != nil || !existed || len(recorded) > 0 {
+		return err
+	}
+	return updateRegionInfo(id, name, lang)
+}
+
+// fillRegionNames to fill the empty names of the language from the region to its parents.
+func fillRegionNames(regionID int64, info placeInfo, lang string) error {
+	for regionID != 0 {
+		existed, level, parentID, name, err := getRegionInfo(regionID, lang)
+		if err != nil || !existed {
+			return err
+		}
+
+		if len(name) == 0 && level >= 1 && level <= regionLevels && len(info.RegionNames[level-1]) > 0 {
+			if err = updateRegionInfo(regionID, info.RegionNames[level-1], lang); err != nil {
+				return err
 			}
 		}
+		regionID = parentID
 	}
-	return cityName, cityAddress, nil
+	return nil
+}
+
+// handleCityTimezone to resolve the timezone of a city if it is not recorded.
+// Return timezone, error
+func handleCityTimezone(placeid string, lat, lng float64) (string, error) {
+	_, zone, err := getCityTimezone(placeid)
+	if err != nil || len(zone) > 0 {
+		return zone, err
+	}
+
+	if zone, err = timezoneProvider.Timezone(lat, lng); err != nil {
+		return "", err
+	}
+	return zone, updateCityTimezone(placeid, zone)
+}
+
+// refreshPlaceInfo to re-resolve an obsolete placeid with the recorded location.
+// notFound is returned if the location is not recorded.
+func refreshPlaceInfo(ctx context.Context, placeid, lang string, notFound error) (placeInfo, error) {
+	located, lat, lng, err := getCityLocation(placeid)
+	if err != nil {
+		return placeInfo{}, err
+	} else if !located {
+		return placeInfo{}, notFound
+	}
+
+	info, _, err := requestLocationWithLatLng(ctx, float32(lat), float32(lng), lang, cityResultTypes)
+	return info, err
+}
+
+// ResolvePlaceID to get the current placeid of a recorded one.
+// The placeid is returned as it is if it has never been replaced.
+func ResolvePlaceID(placeid string) (string, error) {
+	return getCurrentPlaceID(placeid)
+}
+
+// GetCityWithPlaceID to get city information with placeid.
+// An obsolete placeid is resolved to the current one.
+// Return current placeid, name, address, error
+func GetCityWithPlaceID(placeid string, langIndex int, policy ...NamePolicy) (string, string, string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return "", "", "", err
+	}
+	return handleCityInfo(context.Background(), placeid, lang, getNamePolicy(policy))
+}
+
+// RefreshCity to request city information from Google again even it is recorded.
+// It is used to detect an obsolete placeid and re-resolve it.
+// Return current placeid, name, address, error
+func RefreshCity(placeid string, langIndex int, policy ...NamePolicy) (string, string, string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if placeid, err = getCurrentPlaceID(placeid); err != nil {
+		return "", "", "", err
+	}
+
+	var cityExist bool
+	if cityExist, _, _, err = getCityInfo(placeid, lang, NameDefault); err != nil {
+		return "", "", "", err
+	}
+	return fetchCityInfo(context.Background(), placeid, lang, getNamePolicy(policy), cityExist)
+}
+
+// PurgeCity to delete a recorded city, it will be requested from Google again when it is looked up.
+// If the city is not recorded, return ErrNoPlace.
+func PurgeCity(placeid string) error {
+	placeid, err := getCurrentPlaceID(placeid)
+	if err != nil {
+		return err
+	}
+
+	var existed bool
+	if existed, err = deleteCityInfo(placeid); err != nil {
+		return err
+	} else if !existed {
+		return ErrNoPlace
+	}
+	return nil
+}
+
+// GetStats to get the statistics of the recorded data.
+func GetStats() (Stats, error) {
+	return getStats(getAll())
 }
 
 // GetCountries to get all the countries.
 // Return country ids, names, error
-func GetCountries(langIndex int) ([]string, []string, error) {
+func GetCountries(langIndex int, policy ...NamePolicy) ([]string, []string, error) {
 	lang, err := getLanguage(langIndex)
 	if err != nil {
 		return nil, nil, err
 	}
-	return getCountries(lang)
+	return getCountries(lang, getNamePolicy(policy))
+}
+
+// GetCountry to get a country with its metadata.
+// Return country existed, country, error
+func GetCountry(id string, langIndex int, policy ...NamePolicy) (bool, Country, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return false, Country{}, err
+	}
+	return getCountry(id, lang, getNamePolicy(policy))
+}
+
+// GetCountriesInfo to get all the countries with their metadata.
+func GetCountriesInfo(langIndex int, policy ...NamePolicy) ([]Country, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, err
+	}
+	return getCountriesInfo(lang, getNamePolicy(policy))
 }
 
 // GetCityWithLatLng to get city information with lat and lng.
+// Use GetCityWithLatLngOptions to fall back to higher administrative levels.
+// Return placeid, name, address, error
+func GetCityWithLatLng(lat, lng float32, langIndex int, policy ...NamePolicy) (string, string, string, error) {
+	result, err := GetCityWithLatLngOptions(lat, lng, langIndex, ReverseOptions{Policy: getNamePolicy(policy)})
+	return result.PlaceID, result.Name, result.Address, err
+}
+
+// GetCityWithAddress to get the city of a free-form address, such as "1 Infinite Loop, Cupertino".
 // Return placeid, name, address, error
-func GetCityWithLatLng(lat, lng float32, langIndex int) (string, string, string, error) {
+func GetCityWithAddress(address string, langIndex int, policy ...NamePolicy) (string, string, string, error) {
 	lang, err := getLanguage(langIndex)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	var placeid string
-	placeid, err = requestLocationWithLatLng(lat, lng)
+	if len(strings.TrimSpace(address)) == 0 {
+		return "", "", "", ErrNoPlace
+	}
+
+	var info placeInfo
+	info, err = requestLocationWithAddress(context.Background(), address, lang)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	var name, address string
-	name, address, err = handleCityInfo(placeid, lang)
-	return placeid, name, address, err
+	return handleGeocodedCity(context.Background(), info, lang, getNamePolicy(policy))
 }
 
 // GetCitiesWithInput to get cities with input.
+// If some cities failed, the names of them are empty and the first error is returned,
+// use SearchCityResults to get the error of each city.
 // Return place ids, city names, addresses, error
-func GetCitiesWithInput(input string, langIndex int) ([]string, []string, []string, error) {
-	var placeIDs, cityNames, cityAddresses []string
+func GetCitiesWithInput(input string, langIndex int, policy ...NamePolicy) ([]string, []string, []string, error) {
 	lang, err := getLanguage(langIndex)
 	if err != nil {
-		return placeIDs, cityNames, cityAddresses, err
+		return nil, nil, nil, err
 	}
+	return splitCityResults(getCityResultsWithInput(context.Background(), input, lang, SearchOptions{Policy: getNamePolicy(policy)}))
+}
 
-	placeIDs, cityAddresses, err = requestAutoComplete(input, lang)
+// getCityResultsWithInput to get cities with Google Autocomplete.
+// The address of each city is the description of the prediction.
+// The autocomplete request is a part of the session if opts.SessionToken is not empty,
+// the predictions are returned without Place Details requests then, only the city picked ends the session.
+func getCityResultsWithInput(ctx context.Context, input, lang string, opts SearchOptions) ([]CityResult, error) {
+	placeIDs, descriptions, mainTexts, err := requestAutoComplete(ctx, input, lang, opts.SessionToken, opts.Autocomplete)
 	if err != nil {
-		return placeIDs, cityNames, cityAddresses, err
+		return nil, err
 	}
 
-	cityNames = make([]string, len(placeIDs))
+	if opts.isFast() {
+		return getPredictionResults(placeIDs, descriptions, mainTexts, lang, opts)
+	}
 
-	var wg sync.WaitGroup
+	results, err := resolveCities(ctx, placeIDs, lang, opts.Policy)
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Address = descriptions[i]
+		}
+	}
+	return results, err
+}
+
+// getPredictionResults to get the cities of the predictions without Place Details requests.
+// The recorded name is used if the city is recorded, otherwise the main text of the prediction.
+// The cities not recorded are requested in background one by one if opts.EnrichInBackground.
+func getPredictionResults(placeIDs, descriptions, mainTexts []string, lang string, opts SearchOptions) ([]CityResult, error) {
+	results := make([]CityResult, len(placeIDs))
 	for i, id := range placeIDs {
-		wg.Add(1)
+		results[i] = CityResult{Rank: i, PlaceID: id, Address: descriptions[i]}
+
+		placeid, err := getCurrentPlaceID(id)
+		if err != nil {
+			return nil, err
+		}
 
-		go func(thisID string, index int) {
-			defer wg.Done()
+		_, results[i].Name, _, err = getCityInfo(placeid, lang, opts.Policy)
+		if err != nil {
+			return nil, err
+		}
 
-			var cityName string
-			cityName, _, err = handleCityInfo(thisID, lang)
-			cityNames[index] = cityName
-		}(id, i)
-	}
-	wg.Wait()
+		if len(results[i].Name) > 0 {
+			continue
+		}
 
-	return placeIDs, cityNames, cityAddresses, err
+		results[i].Name = mainTexts[i]
+		if opts.EnrichInBackground {
+			cityEnricher.enqueue(enrichTask{placeid: placeid, lang: lang, policy: opts.Policy})
+		}
+	}
+	return results, nil
 }
 
 // GetCountryCities to get all the cities in one country.
 // Return city ids, names, addresses, error
-func GetCountryCities(countryID string, langIndex int) ([]string, []string, []string, error) {
+func GetCountryCities(countryID string, langIndex int, policy ...NamePolicy) ([]string, []string, []string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return getCountryCities(countryID, lang, getNamePolicy(policy))
+}
+
+// GetCityNameType to get the address component type which the city name comes from, such as locality or postal_town.
+func GetCityNameType(placeid string) (string, error) {
+	placeid, err := getCurrentPlaceID(placeid)
+	if err != nil {
+		return "", err
+	}
+	return getCityNameType(placeid)
+}
+
+// GetCityTimezone to get the IANA timezone of a recorded city, such as Asia/Shanghai.
+// The timezone is resolved with the recorded location if it is not recorded.
+// If the city is not recorded, return ErrNoPlace.
+func GetCityTimezone(placeid string) (string, error) {
+	placeid, err := getCurrentPlaceID(placeid)
+	if err != nil {
+		return "", err
+	}
+
+	existed, zone, err := getCityTimezone(placeid)
+	if err != nil || len(zone) > 0 {
+		return zone, err
+	} else if !existed {
+		return "", ErrNoPlace
+	}
+
+	located, lat, lng, err := getCityLocation(placeid)
+	if err != nil {
+		return "", err
+	} else if !located {
+		return "", ErrNoTimezone
+	}
+	return handleCityTimezone(placeid, lat, lng)
+}
+
+// GetCityLocalTime to get the current local time of a recorded city.
+func GetCityLocalTime(placeid string) (time.Time, error) {
+	zone, err := GetCityTimezone(placeid)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return getLocalTime(zone)
+}
+
+// GetCountryRegions to get the top level regions, such as provinces or states, in one country.
+// Return region ids, names, error
+func GetCountryRegions(countryID string, langIndex int) ([]int64, []string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getCountryRegions(countryID, lang)
+}
+
+// GetSubregions to get the regions inside one region, such as prefectures in a province.
+// Return region ids, names, error
+func GetSubregions(regionID int64, langIndex int) ([]int64, []string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getSubregions(regionID, lang)
+}
+
+// GetRegionCities to get all the cities in one region, including its subregions.
+// Return city ids, names, addresses, error
+func GetRegionCities(regionID int64, langIndex int, policy ...NamePolicy) ([]string, []string, []string, error) {
 	lang, err := getLanguage(langIndex)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	return getCountryCities(countryID, lang)
+	return getRegionCities(regionID, lang, getNamePolicy(policy))
+}
+
+// GetCityRegions to get the regions of a recorded city, from the top level to the most specific one.
+// Return region ids, names, error
+func GetCityRegions(placeid string, langIndex int) ([]int64, []string, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if placeid, err = getCurrentPlaceID(placeid); err != nil {
+		return nil, nil, err
+	}
+
+	var regionID int64
+	if regionID, err = getCityRegionID(placeid); err != nil {
+		return nil, nil, err
+	}
+
+	var ids []int64
+	var names []string
+	for regionID != 0 {
+		existed, _, parentID, name, err := getRegionInfo(regionID, lang)
+		if err != nil {
+			return nil, nil, err
+		} else if !existed {
+			break
+		}
+
+		ids = append([]int64{regionID}, ids...)
+		names = append([]string{name}, names...)
+		regionID = parentID
+	}
+	return ids, names, nil
 }
@@ -1,6 +1,7 @@
 package kkcity
 
 import (
+	"context"
 	"net"
 	"os"
 	"testing"
@@ -41,3 +42,10 @@ func TestMain(t *testing.T) {
 	suite.Run(t, new(dbHandleSuite))
 	suite.Run(t, new(languageHandleSuite))
 }
+
+func TestRecordPlaceInfoWithoutName(t *testing.T) {
+	// the place is not recorded, so the database is not used
+	info := placeInfo{PlaceID: "placeid1", Country: "CN", Address: "Fujian, China"}
+	_, _, _, err := recordPlaceInfo(context.Background(), "placeid1", info, "en", NameDefault, false)
+	assert.Equal(t, ErrNoPlace, err, "Place without city name should not be recorded.")
+}
@@ -0,0 +1,90 @@
+// Package kkcityclient provides kkcity.API against a kkcity server,
+// so that apps can switch between embedded and remote mode with configuration.
+package kkcityclient
+
+import (
+	"errors"
+	"io"
+
+	"github.com/drkaka/kkcity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// The modes of Config.
+const (
+	// ModeEmbedded uses the kkcity package functions, kkcity.Use must be called first.
+	ModeEmbedded = "embedded"
+
+	// ModeHTTP talks to the HTTP server of kkcityd.
+	ModeHTTP = "http"
+
+	// ModeGRPC talks to the gRPC server of kkcityd.
+	ModeGRPC = "grpc"
+)
+
+// ErrMode to define the mode of Config is wrong.
+var ErrMode = errors.New("Mode must be embedded, http or grpc.")
+
+// Config to define how to reach kkcity.
+type Config struct {
+	// Mode is ModeEmbedded, ModeHTTP or ModeGRPC.
+	Mode string
+
+	// Addr is the base URL for ModeHTTP such as http://localhost:8080,
+	// or the target for ModeGRPC such as localhost:9090.
+	Addr string
+
+	// Langs are the languages in use of the server, langIndex of the lookups is the index of it.
+	// It is not used by ModeEmbedded.
+	Langs []string
+}
+
+// Client to define kkcity.API which should be closed after use.
+type Client interface {
+	kkcity.API
+	io.Closer
+}
+
+// embedded to implement Client with kkcity.Embedded.
+type embedded struct {
+	kkcity.Embedded
+}
+
+func (embedded) Close() error {
+	return nil
+}
+
+// New to create the client of the config.
+// ModeGRPC connects without transport security, use NewGRPCClient for other options.
+func New(config Config) (Client, error) {
+	switch config.Mode {
+	case ModeEmbedded:
+		return embedded{}, nil
+	case ModeHTTP:
+		return NewHTTPClient(config.Addr, config.Langs, nil), nil
+	case ModeGRPC:
+		conn, err := grpc.NewClient(config.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+		return NewGRPCClient(conn, config.Langs), nil
+	}
+	return nil, ErrMode
+}
+
+// getLang to get the language code of the index.
+func getLang(langs []string, langIndex int) (string, error) {
+	if langIndex < 0 || langIndex >= len(langs) {
+		return "", kkcity.ErrLanguageIndex
+	}
+	return langs[langIndex], nil
+}
+
+// restoreError to get the kkcity error of the code, or a new error with the message.
+func restoreError(code, message string) error {
+	if err := kkcity.CodeError(code); err != nil {
+		return err
+	}
+	return errors.New(message)
+}
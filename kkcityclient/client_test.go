@@ -0,0 +1,130 @@
+package kkcityclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drkaka/kkcity"
+	"github.com/drkaka/kkcity/kkcitypb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ Client = (*HTTPClient)(nil)
+	_ Client = (*GRPCClient)(nil)
+)
+
+func TestNew(t *testing.T) {
+	client, err := New(Config{Mode: ModeEmbedded})
+	assert.NoError(t, err, "Should be able to create embedded client.")
+	assert.NoError(t, client.Close(), "Should be able to close.")
+
+	_, err = New(Config{Mode: "other"})
+	assert.Equal(t, ErrMode, err, "Mode should be wrong.")
+}
+
+func TestHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cities/reverse":
+			if r.URL.Query().Get("lat") == "0" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":"No place found.","code":"no_place"}`))
+				return
+			}
+			w.Write([]byte(`{"placeid":"pid","name":"` + r.URL.Query().Get("lang") + `","address":"addr"}`))
+		case "/countries/CN/cities":
+			w.Write([]byte(`[{"placeid":"pid","name":"Xiamen","address":"Xiamen, Fujian, China"}]`))
+		case "/cities/autocomplete":
+			w.Write([]byte(`[{"placeid":"pid","name":"Xiamen","address":"Xiamen, Fujian, China"},{"placeid":"pid2","error":"Request too many.","code":"limitation"}]`))
+		case "/countries":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Request too many.","code":"limitation"}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewHTTPClient(ts.URL, []string{"en", "zh"}, nil)
+
+	placeid, name, address, err := client.GetCityWithLatLng(24.5, 118.1, 1)
+	assert.NoError(t, err, "Should be able to get city.")
+	assert.Equal(t, "pid", placeid, "Place ID is wrong.")
+	assert.Equal(t, "zh", name, "Language should be sent.")
+	assert.Equal(t, "addr", address, "Address is wrong.")
+
+	_, _, _, err = client.GetCityWithLatLng(0, 0, 0)
+	assert.Equal(t, kkcity.ErrNoPlace, err, "Error should be restored.")
+
+	_, _, err = client.GetCountries(0)
+	assert.Equal(t, kkcity.ErrLimitation, err, "Error should be restored.")
+
+	_, _, err = client.GetCountries(2)
+	assert.Equal(t, kkcity.ErrLanguageIndex, err, "Language index should be wrong.")
+
+	var placeIDs, names []string
+	placeIDs, names, _, err = client.GetCountryCities("CN", 0)
+	assert.NoError(t, err, "Should be able to get cities.")
+	assert.Equal(t, []string{"pid"}, placeIDs, "Place IDs are wrong.")
+	assert.Equal(t, []string{"Xiamen"}, names, "Names are wrong.")
+
+	placeIDs, names, _, err = client.GetCitiesWithInput("xia", 0)
+	assert.Equal(t, kkcity.ErrLimitation, err, "Error of the city should be restored.")
+	assert.Equal(t, []string{"pid", "pid2"}, placeIDs, "Place IDs are wrong.")
+	assert.Equal(t, []string{"Xiamen", ""}, names, "Names are wrong.")
+}
+
+type fakeServer struct {
+	kkcitypb.UnimplementedKKCityServer
+}
+
+func (fakeServer) GetCityWithLatLng(ctx context.Context, req *kkcitypb.GetCityWithLatLngRequest) (*kkcitypb.City, error) {
+	if req.GetLat() == 0 {
+		st, _ := status.New(codes.NotFound, kkcity.ErrNoPlace.Error()).WithDetails(&errdetails.ErrorInfo{Reason: "no_place", Domain: kkcitypb.ErrorDomain})
+		return nil, st.Err()
+	}
+	return &kkcitypb.City{Placeid: "pid", Name: req.GetLang(), Address: "addr"}, nil
+}
+
+func (fakeServer) GetCitiesWithInput(ctx context.Context, req *kkcitypb.GetCitiesWithInputRequest) (*kkcitypb.GetCitiesResponse, error) {
+	return &kkcitypb.GetCitiesResponse{Cities: []*kkcitypb.City{
+		{Placeid: "pid", Name: "Xiamen"},
+		{Placeid: "pid2", Error: kkcity.ErrNoPlace.Error(), Code: "no_place"},
+	}}, nil
+}
+
+func TestGRPCClient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err, "Should be able to listen.")
+
+	s := grpc.NewServer()
+	kkcitypb.RegisterKKCityServer(s, fakeServer{})
+	go s.Serve(listener)
+	defer s.Stop()
+
+	client, err := New(Config{Mode: ModeGRPC, Addr: listener.Addr().String(), Langs: []string{"en", "zh"}})
+	assert.NoError(t, err, "Should be able to create gRPC client.")
+	defer client.Close()
+
+	placeid, name, _, err := client.GetCityWithLatLng(24.5, 118.1, 1)
+	assert.NoError(t, err, "Should be able to get city.")
+	assert.Equal(t, "pid", placeid, "Place ID is wrong.")
+	assert.Equal(t, "zh", name, "Language should be sent.")
+
+	_, _, _, err = client.GetCityWithLatLng(0, 0, 0)
+	assert.Equal(t, kkcity.ErrNoPlace, err, "Error should be restored.")
+
+	placeIDs, _, _, err := client.GetCitiesWithInput("xia", 0)
+	assert.Equal(t, kkcity.ErrNoPlace, err, "Error of the city should be restored.")
+	assert.Equal(t, []string{"pid", "pid2"}, placeIDs, "Place IDs are wrong.")
+
+	_, _, err = client.GetCountries(0)
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Other error should be kept.")
+}
@@ -0,0 +1,125 @@
+package kkcityclient
+
+import (
+	"context"
+	"io"
+
+	"github.com/drkaka/kkcity/kkcitypb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCClient to implement kkcity.API against the gRPC server of kkcityd.
+type GRPCClient struct {
+	client kkcitypb.KKCityClient
+	conn   grpc.ClientConnInterface
+	langs  []string
+}
+
+// NewGRPCClient to create the gRPC client, the connection is closed by Close if it is an io.Closer.
+func NewGRPCClient(conn grpc.ClientConnInterface, langs []string) *GRPCClient {
+	return &GRPCClient{client: kkcitypb.NewKKCityClient(conn), conn: conn, langs: langs}
+}
+
+// fromStatusError to restore the kkcity error from the status error.
+func fromStatusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == kkcitypb.ErrorDomain {
+			return restoreError(info.GetReason(), st.Message())
+		}
+	}
+	return err
+}
+
+// toSlices to split the cities, the error is the first error of the cities if any.
+func toSlices(cities []*kkcitypb.City) ([]string, []string, []string, error) {
+	placeIDs := make([]string, len(cities))
+	names := make([]string, len(cities))
+	addresses := make([]string, len(cities))
+	var err error
+	for i, one := range cities {
+		placeIDs[i], names[i], addresses[i] = one.GetPlaceid(), one.GetName(), one.GetAddress()
+		if err == nil && len(one.GetError()) > 0 {
+			err = restoreError(one.GetCode(), one.GetError())
+		}
+	}
+	return placeIDs, names, addresses, err
+}
+
+// GetCountries to get all the countries.
+func (c *GRPCClient) GetCountries(langIndex int) ([]string, []string, error) {
+	lang, err := getLang(c.langs, langIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.client.GetCountries(context.Background(), &kkcitypb.GetCountriesRequest{Lang: lang})
+	if err != nil {
+		return nil, nil, fromStatusError(err)
+	}
+
+	ids := make([]string, len(resp.GetCountries()))
+	names := make([]string, len(resp.GetCountries()))
+	for i, one := range resp.GetCountries() {
+		ids[i], names[i] = one.GetId(), one.GetName()
+	}
+	return ids, names, nil
+}
+
+// GetCountryCities to get all the cities in one country.
+func (c *GRPCClient) GetCountryCities(countryID string, langIndex int) ([]string, []string, []string, error) {
+	lang, err := getLang(c.langs, langIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resp, err := c.client.GetCountryCities(context.Background(), &kkcitypb.GetCountryCitiesRequest{Lang: lang, CountryId: countryID})
+	if err != nil {
+		return nil, nil, nil, fromStatusError(err)
+	}
+
+	return toSlices(resp.GetCities())
+}
+
+// GetCityWithLatLng to get city information with lat and lng.
+func (c *GRPCClient) GetCityWithLatLng(lat, lng float32, langIndex int) (string, string, string, error) {
+	lang, err := getLang(c.langs, langIndex)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	city, err := c.client.GetCityWithLatLng(context.Background(), &kkcitypb.GetCityWithLatLngRequest{Lang: lang, Lat: lat, Lng: lng})
+	if err != nil {
+		return "", "", "", fromStatusError(err)
+	}
+	return city.GetPlaceid(), city.GetName(), city.GetAddress(), nil
+}
+
+// GetCitiesWithInput to get cities with input.
+func (c *GRPCClient) GetCitiesWithInput(input string, langIndex int) ([]string, []string, []string, error) {
+	lang, err := getLang(c.langs, langIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resp, err := c.client.GetCitiesWithInput(context.Background(), &kkcitypb.GetCitiesWithInputRequest{Lang: lang, Input: input})
+	if err != nil {
+		return nil, nil, nil, fromStatusError(err)
+	}
+
+	return toSlices(resp.GetCities())
+}
+
+// Close to close the connection.
+func (c *GRPCClient) Close() error {
+	if closer, ok := c.conn.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
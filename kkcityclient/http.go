@@ -0,0 +1,133 @@
+package kkcityclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/drkaka/kkcity"
+)
+
+// HTTPClient to implement kkcity.API against the HTTP server of kkcityd.
+type HTTPClient struct {
+	baseURL string
+	langs   []string
+	client  *http.Client
+}
+
+// NewHTTPClient to create the HTTP client, http.DefaultClient is used if client is nil.
+func NewHTTPClient(baseURL string, langs []string, client *http.Client) *HTTPClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPClient{baseURL: strings.TrimRight(baseURL, "/"), langs: langs, client: client}
+}
+
+// httpCity the Error and Code are of the city in a list, empty if the city is resolved.
+type httpCity struct {
+	PlaceID string `json:"placeid"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Error   string `json:"error"`
+	Code    string `json:"code"`
+}
+
+type httpError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// get to request the path and decode the JSON result.
+func (c *HTTPClient) get(path string, query url.Values, langIndex int, result interface{}) error {
+	lang, err := getLang(c.langs, langIndex)
+	if err != nil {
+		return err
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("lang", lang)
+
+	resp, err := c.client.Get(c.baseURL + path + "?" + query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e httpError
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+			return fmt.Errorf("Response status: %d", resp.StatusCode)
+		}
+		return restoreError(e.Code, e.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// getCities to request the path which returns cities, the error is the first error of the cities if any.
+func (c *HTTPClient) getCities(path string, query url.Values, langIndex int) ([]string, []string, []string, error) {
+	var cities []httpCity
+	if err := c.get(path, query, langIndex, &cities); err != nil {
+		return nil, nil, nil, err
+	}
+
+	placeIDs := make([]string, len(cities))
+	names := make([]string, len(cities))
+	addresses := make([]string, len(cities))
+	var err error
+	for i, one := range cities {
+		placeIDs[i], names[i], addresses[i] = one.PlaceID, one.Name, one.Address
+		if err == nil && len(one.Error) > 0 {
+			err = restoreError(one.Code, one.Error)
+		}
+	}
+	return placeIDs, names, addresses, err
+}
+
+// GetCountries to get all the countries.
+func (c *HTTPClient) GetCountries(langIndex int) ([]string, []string, error) {
+	var countries []kkcity.Country
+	if err := c.get("/countries", nil, langIndex, &countries); err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, len(countries))
+	names := make([]string, len(countries))
+	for i, one := range countries {
+		ids[i], names[i] = one.ID, one.Name
+	}
+	return ids, names, nil
+}
+
+// GetCountryCities to get all the cities in one country.
+func (c *HTTPClient) GetCountryCities(countryID string, langIndex int) ([]string, []string, []string, error) {
+	return c.getCities("/countries/"+url.PathEscape(countryID)+"/cities", nil, langIndex)
+}
+
+// GetCityWithLatLng to get city information with lat and lng.
+func (c *HTTPClient) GetCityWithLatLng(lat, lng float32, langIndex int) (string, string, string, error) {
+	query := url.Values{}
+	query.Set("lat", fmt.Sprint(lat))
+	query.Set("lng", fmt.Sprint(lng))
+
+	var city httpCity
+	if err := c.get("/cities/reverse", query, langIndex, &city); err != nil {
+		return "", "", "", err
+	}
+	return city.PlaceID, city.Name, city.Address, nil
+}
+
+// GetCitiesWithInput to get cities with input.
+func (c *HTTPClient) GetCitiesWithInput(input string, langIndex int) ([]string, []string, []string, error) {
+	query := url.Values{}
+	query.Set("input", input)
+	return c.getCities("/cities/autocomplete", query, langIndex)
+}
+
+// Close to close the client, it does nothing.
+func (c *HTTPClient) Close() error {
+	return nil
+}
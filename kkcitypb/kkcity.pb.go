@@ -0,0 +1,615 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v5.29.3
+// source: kkcitypb/kkcity.proto
+
+package kkcitypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type City struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Placeid string                 `protobuf:"bytes,1,opt,name=placeid,proto3" json:"placeid,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// error is the error of the city in a list, empty if the city is resolved.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// code is the kkcity error code of error, such as no_place.
+	Code string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	// timezone is the IANA timezone of the city, such as Asia/Shanghai, empty if it is not resolved.
+	Timezone      string `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *City) GetPlaceid() string {
+	if x != nil {
+		return x.Placeid
+	}
+	return ""
+}
+
+func (x *City) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *City) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *City) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *City) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *City) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type Country struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ISO 3166 alpha-2 code, such as CN.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Alpha3        string `protobuf:"bytes,2,opt,name=alpha3,proto3" json:"alpha3,omitempty"`
+	Numeric       string `protobuf:"bytes,3,opt,name=numeric,proto3" json:"numeric,omitempty"`
+	Name          string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Continent     string `protobuf:"bytes,5,opt,name=continent,proto3" json:"continent,omitempty"`
+	Currency      string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CallingCode   string `protobuf:"bytes,7,opt,name=calling_code,json=callingCode,proto3" json:"calling_code,omitempty"`
+	Capital       string `protobuf:"bytes,8,opt,name=capital,proto3" json:"capital,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Country) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Country) GetAlpha3() string {
+	if x != nil {
+		return x.Alpha3
+	}
+	return ""
+}
+
+func (x *Country) GetNumeric() string {
+	if x != nil {
+		return x.Numeric
+	}
+	return ""
+}
+
+func (x *Country) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Country) GetContinent() string {
+	if x != nil {
+		return x.Continent
+	}
+	return ""
+}
+
+func (x *Country) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Country) GetCallingCode() string {
+	if x != nil {
+		return x.CallingCode
+	}
+	return ""
+}
+
+func (x *Country) GetCapital() string {
+	if x != nil {
+		return x.Capital
+	}
+	return ""
+}
+
+type GetCountriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountriesRequest) Reset() {
+	*x = GetCountriesRequest{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountriesRequest) ProtoMessage() {}
+
+func (x *GetCountriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCountriesRequest) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCountriesRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type GetCountriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Countries     []*Country             `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountriesResponse) Reset() {
+	*x = GetCountriesResponse{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountriesResponse) ProtoMessage() {}
+
+func (x *GetCountriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCountriesResponse) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCountriesResponse) GetCountries() []*Country {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+type GetCountryCitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	CountryId     string                 `protobuf:"bytes,2,opt,name=country_id,json=countryId,proto3" json:"country_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCountryCitiesRequest) Reset() {
+	*x = GetCountryCitiesRequest{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCountryCitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountryCitiesRequest) ProtoMessage() {}
+
+func (x *GetCountryCitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountryCitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCountryCitiesRequest) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCountryCitiesRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetCountryCitiesRequest) GetCountryId() string {
+	if x != nil {
+		return x.CountryId
+	}
+	return ""
+}
+
+type GetCityWithLatLngRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Lat           float32                `protobuf:"fixed32,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float32                `protobuf:"fixed32,3,opt,name=lng,proto3" json:"lng,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCityWithLatLngRequest) Reset() {
+	*x = GetCityWithLatLngRequest{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCityWithLatLngRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCityWithLatLngRequest) ProtoMessage() {}
+
+func (x *GetCityWithLatLngRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCityWithLatLngRequest.ProtoReflect.Descriptor instead.
+func (*GetCityWithLatLngRequest) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCityWithLatLngRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetCityWithLatLngRequest) GetLat() float32 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GetCityWithLatLngRequest) GetLng() float32 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+type GetCitiesWithInputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Input         string                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCitiesWithInputRequest) Reset() {
+	*x = GetCitiesWithInputRequest{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCitiesWithInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCitiesWithInputRequest) ProtoMessage() {}
+
+func (x *GetCitiesWithInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCitiesWithInputRequest.ProtoReflect.Descriptor instead.
+func (*GetCitiesWithInputRequest) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCitiesWithInputRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GetCitiesWithInputRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+type GetCitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cities        []*City                `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCitiesResponse) Reset() {
+	*x = GetCitiesResponse{}
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCitiesResponse) ProtoMessage() {}
+
+func (x *GetCitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kkcitypb_kkcity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCitiesResponse) Descriptor() ([]byte, []int) {
+	return file_kkcitypb_kkcity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCitiesResponse) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+var File_kkcitypb_kkcity_proto protoreflect.FileDescriptor
+
+const file_kkcitypb_kkcity_proto_rawDesc = "" +
+	"\n" +
+	"\x15kkcitypb/kkcity.proto\x12\x06kkcity\"\x94\x01\n" +
+	"\x04City\x12\x18\n" +
+	"\aplaceid\x18\x01 \x01(\tR\aplaceid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\"\xd6\x01\n" +
+	"\aCountry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06alpha3\x18\x02 \x01(\tR\x06alpha3\x12\x18\n" +
+	"\anumeric\x18\x03 \x01(\tR\anumeric\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcontinent\x18\x05 \x01(\tR\tcontinent\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12!\n" +
+	"\fcalling_code\x18\a \x01(\tR\vcallingCode\x12\x18\n" +
+	"\acapital\x18\b \x01(\tR\acapital\")\n" +
+	"\x13GetCountriesRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\"E\n" +
+	"\x14GetCountriesResponse\x12-\n" +
+	"\tcountries\x18\x01 \x03(\v2\x0f.kkcity.CountryR\tcountries\"L\n" +
+	"\x17GetCountryCitiesRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x1d\n" +
+	"\n" +
+	"country_id\x18\x02 \x01(\tR\tcountryId\"R\n" +
+	"\x18GetCityWithLatLngRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x02R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x03 \x01(\x02R\x03lng\"E\n" +
+	"\x19GetCitiesWithInputRequest\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x14\n" +
+	"\x05input\x18\x02 \x01(\tR\x05input\"9\n" +
+	"\x11GetCitiesResponse\x12$\n" +
+	"\x06cities\x18\x01 \x03(\v2\f.kkcity.CityR\x06cities2\xbc\x02\n" +
+	"\x06KKCity\x12I\n" +
+	"\fGetCountries\x12\x1b.kkcity.GetCountriesRequest\x1a\x1c.kkcity.GetCountriesResponse\x12N\n" +
+	"\x10GetCountryCities\x12\x1f.kkcity.GetCountryCitiesRequest\x1a\x19.kkcity.GetCitiesResponse\x12C\n" +
+	"\x11GetCityWithLatLng\x12 .kkcity.GetCityWithLatLngRequest\x1a\f.kkcity.City\x12R\n" +
+	"\x12GetCitiesWithInput\x12!.kkcity.GetCitiesWithInputRequest\x1a\x19.kkcity.GetCitiesResponseB#Z!github.com/drkaka/kkcity/kkcitypbb\x06proto3"
+
+var (
+	file_kkcitypb_kkcity_proto_rawDescOnce sync.Once
+	file_kkcitypb_kkcity_proto_rawDescData []byte
+)
+
+func file_kkcitypb_kkcity_proto_rawDescGZIP() []byte {
+	file_kkcitypb_kkcity_proto_rawDescOnce.Do(func() {
+		file_kkcitypb_kkcity_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kkcitypb_kkcity_proto_rawDesc), len(file_kkcitypb_kkcity_proto_rawDesc)))
+	})
+	return file_kkcitypb_kkcity_proto_rawDescData
+}
+
+var file_kkcitypb_kkcity_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_kkcitypb_kkcity_proto_goTypes = []any{
+	(*City)(nil),                      // 0: kkcity.City
+	(*Country)(nil),                   // 1: kkcity.Country
+	(*GetCountriesRequest)(nil),       // 2: kkcity.GetCountriesRequest
+	(*GetCountriesResponse)(nil),      // 3: kkcity.GetCountriesResponse
+	(*GetCountryCitiesRequest)(nil),   // 4: kkcity.GetCountryCitiesRequest
+	(*GetCityWithLatLngRequest)(nil),  // 5: kkcity.GetCityWithLatLngRequest
+	(*GetCitiesWithInputRequest)(nil), // 6: kkcity.GetCitiesWithInputRequest
+	(*GetCitiesResponse)(nil),         // 7: kkcity.GetCitiesResponse
+}
+var file_kkcitypb_kkcity_proto_depIdxs = []int32{
+	1, // 0: kkcity.GetCountriesResponse.countries:type_name -> kkcity.Country
+	0, // 1: kkcity.GetCitiesResponse.cities:type_name -> kkcity.City
+	2, // 2: kkcity.KKCity.GetCountries:input_type -> kkcity.GetCountriesRequest
+	4, // 3: kkcity.KKCity.GetCountryCities:input_type -> kkcity.GetCountryCitiesRequest
+	5, // 4: kkcity.KKCity.GetCityWithLatLng:input_type -> kkcity.GetCityWithLatLngRequest
+	6, // 5: kkcity.KKCity.GetCitiesWithInput:input_type -> kkcity.GetCitiesWithInputRequest
+	3, // 6: kkcity.KKCity.GetCountries:output_type -> kkcity.GetCountriesResponse
+	7, // 7: kkcity.KKCity.GetCountryCities:output_type -> kkcity.GetCitiesResponse
+	0, // 8: kkcity.KKCity.GetCityWithLatLng:output_type -> kkcity.City
+	7, // 9: kkcity.KKCity.GetCitiesWithInput:output_type -> kkcity.GetCitiesResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_kkcitypb_kkcity_proto_init() }
+func file_kkcitypb_kkcity_proto_init() {
+	if File_kkcitypb_kkcity_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kkcitypb_kkcity_proto_rawDesc), len(file_kkcitypb_kkcity_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kkcitypb_kkcity_proto_goTypes,
+		DependencyIndexes: file_kkcitypb_kkcity_proto_depIdxs,
+		MessageInfos:      file_kkcitypb_kkcity_proto_msgTypes,
+	}.Build()
+	File_kkcitypb_kkcity_proto = out.File
+	file_kkcitypb_kkcity_proto_goTypes = nil
+	file_kkcitypb_kkcity_proto_depIdxs = nil
+}
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: kkcitypb/kkcity.proto
+
+package kkcitypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KKCity_GetCountries_FullMethodName       = "/kkcity.KKCity/GetCountries"
+	KKCity_GetCountryCities_FullMethodName   = "/kkcity.KKCity/GetCountryCities"
+	KKCity_GetCityWithLatLng_FullMethodName  = "/kkcity.KKCity/GetCityWithLatLng"
+	KKCity_GetCitiesWithInput_FullMethodName = "/kkcity.KKCity/GetCitiesWithInput"
+)
+
+// KKCityClient is the client API for KKCity service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KKCity mirrors the public API of the kkcity library.
+// lang is an ISO-639-1 code in use, the first language is used if it is empty.
+type KKCityClient interface {
+	// GetCountries to get all the countries.
+	GetCountries(ctx context.Context, in *GetCountriesRequest, opts ...grpc.CallOption) (*GetCountriesResponse, error)
+	// GetCountryCities to get all the recorded cities in one country.
+	GetCountryCities(ctx context.Context, in *GetCountryCitiesRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error)
+	// GetCityWithLatLng to get the city of a location.
+	GetCityWithLatLng(ctx context.Context, in *GetCityWithLatLngRequest, opts ...grpc.CallOption) (*City, error)
+	// GetCitiesWithInput to get the cities matching the input.
+	GetCitiesWithInput(ctx context.Context, in *GetCitiesWithInputRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error)
+}
+
+type kKCityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKKCityClient(cc grpc.ClientConnInterface) KKCityClient {
+	return &kKCityClient{cc}
+}
+
+func (c *kKCityClient) GetCountries(ctx context.Context, in *GetCountriesRequest, opts ...grpc.CallOption) (*GetCountriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCountriesResponse)
+	err := c.cc.Invoke(ctx, KKCity_GetCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kKCityClient) GetCountryCities(ctx context.Context, in *GetCountryCitiesRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCitiesResponse)
+	err := c.cc.Invoke(ctx, KKCity_GetCountryCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kKCityClient) GetCityWithLatLng(ctx context.Context, in *GetCityWithLatLngRequest, opts ...grpc.CallOption) (*City, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(City)
+	err := c.cc.Invoke(ctx, KKCity_GetCityWithLatLng_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kKCityClient) GetCitiesWithInput(ctx context.Context, in *GetCitiesWithInputRequest, opts ...grpc.CallOption) (*GetCitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCitiesResponse)
+	err := c.cc.Invoke(ctx, KKCity_GetCitiesWithInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KKCityServer is the server API for KKCity service.
+// All implementations must embed UnimplementedKKCityServer
+// for forward compatibility.
+//
+// KKCity mirrors the public API of the kkcity library.
+// lang is an ISO-639-1 code in use, the first language is used if it is empty.
+type KKCityServer interface {
+	// GetCountries to get all the countries.
+	GetCountries(context.Context, *GetCountriesRequest) (*GetCountriesResponse, error)
+	// GetCountryCities to get all the recorded cities in one country.
+	GetCountryCities(context.Context, *GetCountryCitiesRequest) (*GetCitiesResponse, error)
+	// GetCityWithLatLng to get the city of a location.
+	GetCityWithLatLng(context.Context, *GetCityWithLatLngRequest) (*City, error)
+	// GetCitiesWithInput to get the cities matching the input.
+	GetCitiesWithInput(context.Context, *GetCitiesWithInputRequest) (*GetCitiesResponse, error)
+	mustEmbedUnimplementedKKCityServer()
+}
+
+// UnimplementedKKCityServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKKCityServer struct{}
+
+func (UnimplementedKKCityServer) GetCountries(context.Context, *GetCountriesRequest) (*GetCountriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountries not implemented")
+}
+func (UnimplementedKKCityServer) GetCountryCities(context.Context, *GetCountryCitiesRequest) (*GetCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountryCities not implemented")
+}
+func (UnimplementedKKCityServer) GetCityWithLatLng(context.Context, *GetCityWithLatLngRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCityWithLatLng not implemented")
+}
+func (UnimplementedKKCityServer) GetCitiesWithInput(context.Context, *GetCitiesWithInputRequest) (*GetCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCitiesWithInput not implemented")
+}
+func (UnimplementedKKCityServer) mustEmbedUnimplementedKKCityServer() {}
+func (UnimplementedKKCityServer) testEmbeddedByValue()                {}
+
+// UnsafeKKCityServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KKCityServer will
+// result in compilation errors.
+type UnsafeKKCityServer interface {
+	mustEmbedUnimplementedKKCityServer()
+}
+
+func RegisterKKCityServer(s grpc.ServiceRegistrar, srv KKCityServer) {
+	// If the following call pancis, it indicates UnimplementedKKCityServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KKCity_ServiceDesc, srv)
+}
+
+func _KKCity_GetCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KKCityServer).GetCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KKCity_GetCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KKCityServer).GetCountries(ctx, req.(*GetCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KKCity_GetCountryCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryCitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KKCityServer).GetCountryCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KKCity_GetCountryCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KKCityServer).GetCountryCities(ctx, req.(*GetCountryCitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KKCity_GetCityWithLatLng_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityWithLatLngRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KKCityServer).GetCityWithLatLng(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KKCity_GetCityWithLatLng_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KKCityServer).GetCityWithLatLng(ctx, req.(*GetCityWithLatLngRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KKCity_GetCitiesWithInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCitiesWithInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KKCityServer).GetCitiesWithInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KKCity_GetCitiesWithInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KKCityServer).GetCitiesWithInput(ctx, req.(*GetCitiesWithInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KKCity_ServiceDesc is the grpc.ServiceDesc for KKCity service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KKCity_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kkcity.KKCity",
+	HandlerType: (*KKCityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCountries",
+			Handler:    _KKCity_GetCountries_Handler,
+		},
+		{
+			MethodName: "GetCountryCities",
+			Handler:    _KKCity_GetCountryCities_Handler,
+		},
+		{
+			MethodName: "GetCityWithLatLng",
+			Handler:    _KKCity_GetCityWithLatLng_Handler,
+		},
+		{
+			MethodName: "GetCitiesWithInput",
+			Handler:    _KKCity_GetCitiesWithInput_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kkcitypb/kkcity.proto",
+}
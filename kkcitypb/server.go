@@ -0,0 +1,161 @@
+// Package kkcitypb provides the gRPC service of kkcity and its generated client.
+package kkcitypb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative kkcitypb/kkcity.proto
+
+import (
+	"context"
+	"errors"
+
+	"github.com/drkaka/kkcity"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server to implement KKCityServer with the kkcity library.
+// kkcity.Use must be called before serving.
+type Server struct {
+	UnimplementedKKCityServer
+}
+
+// NewServer to create the server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// getLangIndex to get the language index of the code, the first language is used if it is empty.
+func getLangIndex(lang string) (int, error) {
+	if len(lang) == 0 {
+		return 0, nil
+	}
+	return kkcity.GetLanguageIndex(lang)
+}
+
+// getCode to get the gRPC code of the error.
+func getCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, kkcity.ErrLanguageIndex), errors.Is(err, kkcity.ErrCountryID), errors.Is(err, kkcity.ErrInvalidRequest):
+		return codes.InvalidArgument
+	case errors.Is(err, kkcity.ErrNoPlace), errors.Is(err, kkcity.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, kkcity.ErrLimitation):
+		return codes.ResourceExhausted
+	case errors.Is(err, kkcity.ErrRequestDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, kkcity.ErrUnknown):
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+// ErrorDomain the domain of the ErrorInfo detail, whose reason is the kkcity error code.
+const ErrorDomain = "kkcity"
+
+// toStatusError to convert the error to a gRPC status error.
+// The kkcity error code is attached as ErrorInfo so that clients can restore the error.
+func toStatusError(err error) error {
+	st := status.New(getCode(err), err.Error())
+	if code := kkcity.ErrorCode(err); len(code) > 0 {
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: code, Domain: ErrorDomain}); detailErr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// toCities to combine the city slices.
+func toCities(placeIDs, names, addresses []string) []*City {
+	cities := make([]*City, len(placeIDs))
+	for i, one := range placeIDs {
+		cities[i] = &City{Placeid: one, Name: names[i], Address: addresses[i]}
+	}
+	return cities
+}
+
+// toResultCities to convert the results, each city carries its own error.
+func toResultCities(results []kkcity.CityResult) []*City {
+	cities := make([]*City, len(results))
+	for i, one := range results {
+		cities[i] = &City{Placeid: one.PlaceID, Name: one.Name, Address: one.Address, Timezone: one.Timezone}
+		if one.Err != nil {
+			cities[i].Error, cities[i].Code = one.Err.Error(), kkcity.ErrorCode(one.Err)
+		}
+	}
+	return cities
+}
+
+// GetCountries to get all the countries.
+func (s *Server) GetCountries(ctx context.Context, req *GetCountriesRequest) (*GetCountriesResponse, error) {
+	langIndex, err := getLangIndex(req.GetLang())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	countries, err := kkcity.GetCountriesInfo(langIndex)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &GetCountriesResponse{Countries: make([]*Country, len(countries))}
+	for i, one := range countries {
+		resp.Countries[i] = &Country{
+			Id:          one.ID,
+			Alpha3:      one.Alpha3,
+			Numeric:     one.Numeric,
+			Name:        one.Name,
+			Continent:   one.Continent,
+			Currency:    one.Currency,
+			CallingCode: one.CallingCode,
+			Capital:     one.Capital,
+		}
+	}
+	return resp, nil
+}
+
+// GetCountryCities to get all the recorded cities in one country.
+func (s *Server) GetCountryCities(ctx context.Context, req *GetCountryCitiesRequest) (*GetCitiesResponse, error) {
+	langIndex, err := getLangIndex(req.GetLang())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	placeIDs, names, addresses, err := kkcity.GetCountryCities(req.GetCountryId(), langIndex)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &GetCitiesResponse{Cities: toCities(placeIDs, names, addresses)}, nil
+}
+
+// GetCityWithLatLng to get the city of a location.
+func (s *Server) GetCityWithLatLng(ctx context.Context, req *GetCityWithLatLngRequest) (*City, error) {
+	langIndex, err := getLangIndex(req.GetLang())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := kkcity.GetCityWithLatLngOptions(req.GetLat(), req.GetLng(), langIndex, kkcity.ReverseOptions{})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &City{Placeid: result.PlaceID, Name: result.Name, Address: result.Address, Timezone: result.Timezone}, nil
+}
+
+// GetCitiesWithInput to get the cities matching the input, each city carries its own error.
+// The error is returned only if no city is returned, such as the autocomplete request fails.
+func (s *Server) GetCitiesWithInput(ctx context.Context, req *GetCitiesWithInputRequest) (*GetCitiesResponse, error) {
+	if len(req.GetInput()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Input is empty.")
+	}
+
+	langIndex, err := getLangIndex(req.GetLang())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	results, err := kkcity.SearchCityResults(ctx, req.GetInput(), langIndex, kkcity.SearchOptions{})
+	if err != nil && len(results) == 0 {
+		return nil, toStatusError(err)
+	}
+	return &GetCitiesResponse{Cities: toResultCities(results)}, nil
+}
@@ -0,0 +1,44 @@
+package kkcitypb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/drkaka/kkcity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetCode(t *testing.T) {
+	assert.Equal(t, codes.NotFound, getCode(kkcity.ErrNoPlace), "No place should be not found.")
+	assert.Equal(t, codes.ResourceExhausted, getCode(kkcity.ErrLimitation), "Limitation should be resource exhausted.")
+	assert.Equal(t, codes.InvalidArgument, getCode(kkcity.ErrLanguageIndex), "Language should be invalid argument.")
+	assert.Equal(t, codes.Internal, getCode(errors.New("other")), "Other error should be internal.")
+}
+
+func TestServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err, "Should be able to listen.")
+
+	s := grpc.NewServer()
+	RegisterKKCityServer(s, NewServer())
+	go s.Serve(listener)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err, "Should be able to connect.")
+	defer conn.Close()
+
+	client := NewKKCityClient(conn)
+
+	_, err = client.GetCitiesWithInput(context.Background(), &GetCitiesWithInputRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Empty input should be invalid argument.")
+
+	_, err = client.GetCountries(context.Background(), &GetCountriesRequest{Lang: "xx"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Language should be invalid argument.")
+}
@@ -36,3 +36,20 @@ func getLanguage(tp int) (string, error) {
 func getAll() []string {
 	return languages
 }
+
+// GetLanguages to get the languages in use, the index is used as langIndex.
+func GetLanguages() []string {
+	return append([]string(nil), languages...)
+}
+
+// GetLanguageIndex to get the index of a language in use.
+// If the language is not in use, return ErrLanguageIndex.
+func GetLanguageIndex(lang string) (int, error) {
+	lang = strings.ToLower(lang)
+	for i, one := range languages {
+		if one == lang {
+			return i, nil
+		}
+	}
+	return -1, ErrLanguageIndex
+}
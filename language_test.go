@@ -26,3 +26,14 @@ func (suite *languageHandleSuite) TestGetAllLanguage() {
 	all := getAll()
 	suite.EqualValues(testLangs, all, "Get all languages is wrong.")
 }
+
+func (suite *languageHandleSuite) TestGetLanguageIndex() {
+	index, err := GetLanguageIndex("ZH")
+	suite.NoError(err, "Should be able to get language index.")
+	suite.Equal(1, index, "Language index is wrong.")
+
+	_, err = GetLanguageIndex("fr")
+	suite.Equal(ErrLanguageIndex, err, "Language is not in use.")
+
+	suite.EqualValues(testLangs, GetLanguages(), "Get languages is wrong.")
+}
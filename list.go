@@ -0,0 +1,125 @@
+package kkcity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// SortOrder to define the order of a listing.
+type SortOrder int
+
+const (
+	// SortByID sorts by the placeid of cities or the id of countries.
+	SortByID SortOrder = iota
+
+	// SortByName sorts by the localized name with the collation of the language.
+	SortByName
+)
+
+// ErrCursor to define the cursor is wrong or from another sort order.
+var ErrCursor = errors.New("Cursor is wrong.")
+
+// ListOptions to define how a listing is paginated, sorted and filtered.
+type ListOptions struct {
+	// Limit is the max count of one page, 0 means no limitation.
+	Limit int
+
+	// Cursor is the NextCursor of the previous page, empty for the first page.
+	Cursor string
+
+	Sort       SortOrder
+	Descending bool
+
+	// Policy chooses the names returned and sorted by.
+	Policy NamePolicy
+
+	// NamedOnly lists only the ones having a name in the language.
+	NamedOnly bool
+}
+
+// CityPage to define one page of cities.
+type CityPage struct {
+	PlaceIDs  []string
+	Names     []string
+	Addresses []string
+
+	// NextCursor is empty if it is the last page.
+	NextCursor string
+}
+
+// CountryPage to define one page of countries.
+type CountryPage struct {
+	Countries []Country
+
+	// NextCursor is empty if it is the last page.
+	NextCursor string
+}
+
+// listCursor to define the position after the last one of a page.
+type listCursor struct {
+	Sort       SortOrder `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	Name       string    `json:"n,omitempty"`
+	ID         string    `json:"i"`
+}
+
+// encode to get the opaque cursor string.
+func (c listCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor to get the cursor of the options, nil if it is the first page.
+func decodeCursor(opts ListOptions) (*listCursor, error) {
+	if len(opts.Cursor) == 0 {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(opts.Cursor)
+	if err != nil {
+		return nil, ErrCursor
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil || len(c.ID) == 0 {
+		return nil, ErrCursor
+	} else if c.Sort != opts.Sort || c.Descending != opts.Descending {
+		return nil, ErrCursor
+	}
+	return &c, nil
+}
+
+// SetCollation to set the PostgreSQL collation used to sort the names of a language, such as zh-x-icu.
+// The ICU collation of the language is used by default if the database has it, otherwise the database default.
+// An empty collation uses the database default.
+func SetCollation(lang, collation string) {
+	collations[lang] = collation
+}
+
+// ListCountries to get one page of the countries with their metadata.
+func ListCountries(langIndex int, opts ListOptions) (CountryPage, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return CountryPage{}, err
+	}
+	return listCountries(lang, opts)
+}
+
+// ListCountryCities to get one page of the cities in one country.
+func ListCountryCities(countryID string, langIndex int, opts ListOptions) (CityPage, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return CityPage{}, err
+	}
+	return listCities(lang, opts, "country_id=$1", countryID)
+}
+
+// ListRegionCities to get one page of the cities in one region, including its subregions.
+func ListRegionCities(regionID int64, langIndex int, opts ListOptions) (CityPage, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return CityPage{}, err
+	}
+	return listCities(lang, opts, "(region_id=$1 OR region_id IN (SELECT id FROM region_info WHERE parent_id=$1))", regionID)
+}
@@ -0,0 +1,53 @@
+package kkcity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCursor(t *testing.T) {
+	opts := ListOptions{Sort: SortByName, Descending: true}
+	opts.Cursor = listCursor{Sort: SortByName, Descending: true, Name: "厦门", ID: "placeid1"}.encode()
+
+	cursor, err := decodeCursor(opts)
+	assert.NoError(t, err, "Should be able to decode cursor.")
+	assert.Equal(t, "厦门", cursor.Name, "Cursor name is wrong.")
+	assert.Equal(t, "placeid1", cursor.ID, "Cursor id is wrong.")
+
+	cursor, err = decodeCursor(ListOptions{})
+	assert.NoError(t, err, "First page has no cursor.")
+	assert.Nil(t, cursor, "First page has no cursor.")
+
+	opts.Sort = SortByID
+	_, err = decodeCursor(opts)
+	assert.Equal(t, ErrCursor, err, "Cursor of another sort order should be wrong.")
+
+	_, err = decodeCursor(ListOptions{Cursor: "wrong"})
+	assert.Equal(t, ErrCursor, err, "Cursor should be wrong.")
+}
+
+func TestGetListClause(t *testing.T) {
+	collations["en"] = "en-x-icu"
+	defer delete(collations, "en")
+
+	clause, args, err := getListClause("name_en", "placeid", "en", ListOptions{}, []string{"country_id=$1"}, []interface{}{"CN"})
+	assert.NoError(t, err, "Should be able to get clause.")
+	assert.Equal(t, "WHERE country_id=$1 ORDER BY placeid ASC", clause, "Clause is wrong.")
+	assert.Equal(t, []interface{}{"CN"}, args, "Args are wrong.")
+
+	opts := ListOptions{Limit: 10, Sort: SortByName, NamedOnly: true}
+	opts.Cursor = listCursor{Sort: SortByName, Name: "Xiamen", ID: "placeid1"}.encode()
+	clause, args, err = getListClause("name_en", "placeid", "en", opts, []string{"country_id=$1"}, []interface{}{"CN"})
+	assert.NoError(t, err, "Should be able to get clause.")
+	assert.Equal(t, `WHERE country_id=$1 AND COALESCE(name_en,'')<>'' AND `+
+		`(COALESCE(name_en,'') COLLATE "en-x-icu">$2 OR (COALESCE(name_en,'') COLLATE "en-x-icu"=$2 AND placeid>$3)) `+
+		`ORDER BY COALESCE(name_en,'') COLLATE "en-x-icu" ASC,placeid ASC LIMIT $4`, clause, "Clause is wrong.")
+	assert.Equal(t, []interface{}{"CN", "Xiamen", "placeid1", 11}, args, "Args are wrong.")
+
+	opts = ListOptions{Descending: true}
+	opts.Cursor = listCursor{Descending: true, ID: "US"}.encode()
+	clause, _, err = getListClause("name_en", "id", "en", opts, nil, nil)
+	assert.NoError(t, err, "Should be able to get clause.")
+	assert.Equal(t, "WHERE id<$1 ORDER BY id DESC", clause, "Clause is wrong.")
+}
@@ -0,0 +1,57 @@
+package kkcity
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter to space the requests sent to Google evenly.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// googleLimiter the limiter of all the requests sent to Google.
+var googleLimiter rateLimiter
+
+// SetRateLimit to set the max count of the requests sent to Google per second, 0 means no limitation.
+// The requests over the limitation wait for their turn instead of failing with ErrLimitation.
+func SetRateLimit(perSecond float64) {
+	googleLimiter.mu.Lock()
+	defer googleLimiter.mu.Unlock()
+
+	googleLimiter.interval = 0
+	if perSecond > 0 {
+		googleLimiter.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+}
+
+// wait to wait until the request can be sent.
+// Return the error of ctx if it is done before the turn, the turn is not given back.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	if l.interval <= 0 {
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,36 @@
+package kkcity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var limiter rateLimiter
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		limiter.wait(context.Background())
+	}
+	assert.True(t, time.Since(start) < 10*time.Millisecond, "There should be no limitation.")
+
+	limiter.interval = 20 * time.Millisecond
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		limiter.wait(context.Background())
+	}
+	assert.True(t, time.Since(start) >= 40*time.Millisecond, "Requests should be spaced.")
+
+	// the request waiting for its turn is aborted when ctx is done
+	limiter.interval = time.Second
+	limiter.wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	assert.Equal(t, context.DeadlineExceeded, limiter.wait(ctx), "Waiting should be aborted.")
+	assert.True(t, time.Since(start) < 500*time.Millisecond, "Waiting should not last for the turn.")
+}
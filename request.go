@@ -1,12 +1,14 @@
 package kkcity
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
-
-	"github.com/parnurzeal/gorequest"
 )
 
 var (
@@ -15,13 +17,89 @@ var (
 
 	// ErrLimitation to define request over the limitation.
 	ErrLimitation = errors.New("Request too many.")
+
+	// ErrRequestDenied to define the request is denied, normally the key is wrong.
+	ErrRequestDenied = errors.New("Request denied.")
+
+	// ErrInvalidRequest to define the request is missing or has wrong parameters.
+	ErrInvalidRequest = errors.New("Invalid request.")
+
+	// ErrNotFound to define the place ID is no longer valid.
+	ErrNotFound = errors.New("Place not found.")
+
+	// ErrUnknown to define a server side error, the request may succeed if tried again.
+	ErrUnknown = errors.New("Unknown error.")
 )
 
+// StatusError to define a failed status returned by Google API.
+// It matches the corresponding Err* value with errors.Is.
+type StatusError struct {
+	// Status is the status field of the response, such as REQUEST_DENIED.
+	Status string
+
+	// Message is the error_message field of the response, may be empty.
+	Message string
+
+	err error
+}
+
+func (e *StatusError) Error() string {
+	if len(e.Message) == 0 {
+		return fmt.Sprintf("%s %s", e.err.Error(), e.Status)
+	}
+	return fmt.Sprintf("%s %s: %s", e.err.Error(), e.Status, e.Message)
+}
+
+// Unwrap to get the Err* value of the status.
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
 // googleKey used to request the APIs.
 var googleKey string
 
+// nameTypes the address component types tried in order for the city name.
+var nameTypes = []string{"locality", "postal_town", "administrative_area_level_3", "sublocality_level_1"}
+
+// SetCityNameTypes to set the address component types tried in order for the city name.
+// The default is locality, postal_town, administrative_area_level_3, sublocality_level_1.
+func SetCityNameTypes(types []string) {
+	nameTypes = append([]string(nil), types...)
+}
+
 type statusField struct {
-	Status string `json:"status"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// statusErrors to define the errors of the failed statuses.
+var statusErrors = map[string]error{
+	"ZERO_RESULTS":     ErrNoPlace,
+	"OVER_QUERY_LIMIT": ErrLimitation,
+	"REQUEST_DENIED":   ErrRequestDenied,
+
+	// OVER_DAILY_LIMIT means the key is missing or invalid, or the billing is not enabled, it is not transient
+	"OVER_DAILY_LIMIT": ErrRequestDenied,
+	"INVALID_REQUEST":  ErrInvalidRequest,
+	"NOT_FOUND":        ErrNotFound,
+	"UNKNOWN_ERROR":    ErrUnknown,
+}
+
+// err to get the error of the status, nil if status is OK.
+// ErrNoPlace and ErrLimitation are returned directly so they can be compared with ==,
+// the others are returned as *StatusError, use errors.Is to check the Err* value.
+func (s statusField) err() error {
+	if s.Status == "OK" {
+		return nil
+	}
+
+	e, ok := statusErrors[s.Status]
+	if !ok {
+		return &StatusError{Status: s.Status, Message: s.ErrorMessage, err: errors.New("Unhandled result.")}
+	} else if s.Status == "ZERO_RESULTS" || s.Status == "OVER_QUERY_LIMIT" {
+		return e
+	}
+	return &StatusError{Status: s.Status, Message: s.ErrorMessage, err: e}
 }
 
 type oneAddress struct {
@@ -31,10 +109,55 @@ type oneAddress struct {
 }
 
 type oneLatLngResult struct {
-	AddressComponents json.RawMessage `json:"address_components"`
-	Formatted         json.RawMessage `json:"formatted_address"`
-	Geometry          json.RawMessage `json:"geometry"`
-	PlaceID           string          `json:"place_id"`
+	AddressComponents []oneAddress  `json:"address_components"`
+	Formatted         string        `json:"formatted_address"`
+	Geometry          placeGeometry `json:"geometry"`
+	PlaceID           string        `json:"place_id"`
+	Types             []string      `json:"types"`
+}
+
+// placeInfo to get the place information of the geocoding result, it is in the language requested.
+func (r oneLatLngResult) placeInfo() placeInfo {
+	return getPlaceInfo(placeDetailResult{
+		AddressComponents: r.AddressComponents,
+		Address:           r.Formatted,
+		Geometry:          r.Geometry,
+		PlaceID:           r.PlaceID,
+		Types:             r.Types,
+	})
+}
+
+// hasType to check whether the result is of the type.
+func (r oneLatLngResult) hasType(tp string) bool {
+	for _, one := range r.Types {
+		if one == tp {
+			return true
+		}
+	}
+	return false
+}
+
+// isCity to check whether the result is a city, which has one of the city name types.
+func (r oneLatLngResult) isCity() bool {
+	for _, tp := range nameTypes {
+		if r.hasType(tp) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickLatLngResult to pick the result of the first type in order.
+// Return the result, the type matched, whether found.
+func pickLatLngResult(results []oneLatLngResult, types []string) (oneLatLngResult, string, bool) {
+	for _, tp := range types {
+		for _, one := range results {
+			if one.hasType(tp) {
+				return one, tp, true
+			}
+		}
+	}
+	return oneLatLngResult{}, "", false
 }
 
 // latLngLocation to define the result of search result with lat and lng.
@@ -43,14 +166,35 @@ type latLngLocation struct {
 	statusField
 }
 
+type predictTerm struct {
+	Offset int    `json:"offset"`
+	Value  string `json:"value"`
+}
+
+type predictFormatting struct {
+	MainText      string `json:"main_text"`
+	SecondaryText string `json:"secondary_text"`
+}
+
 type predictResult struct {
-	ID          json.RawMessage `json:"id"`
-	Reference   json.RawMessage `json:"reference"`
-	Matched     json.RawMessage `json:"matched_substrings"`
-	Description string          `json:"description"`
-	PlaceID     string          `json:"place_id"`
-	Types       json.RawMessage `json:"types"`
-	Terms       json.RawMessage `json:"terms"`
+	ID          json.RawMessage   `json:"id"`
+	Reference   json.RawMessage   `json:"reference"`
+	Matched     json.RawMessage   `json:"matched_substrings"`
+	Description string            `json:"description"`
+	PlaceID     string            `json:"place_id"`
+	Types       json.RawMessage   `json:"types"`
+	Terms       []predictTerm     `json:"terms"`
+	Formatting  predictFormatting `json:"structured_formatting"`
+}
+
+// mainText to get the name of the predicted place, the first term is used if there is no structured formatting.
+func (p predictResult) mainText() string {
+	if len(p.Formatting.MainText) > 0 {
+		return p.Formatting.MainText
+	} else if len(p.Terms) > 0 {
+		return p.Terms[0].Value
+	}
+	return ""
 }
 
 type predictLocation struct {
@@ -58,9 +202,32 @@ type predictLocation struct {
 	statusField
 }
 
+type latLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type placeGeometry struct {
+	Location latLng `json:"location"`
+}
+
 type placeDetailResult struct {
-	AddressComponents []oneAddress `json:"address_components"`
-	Address           string       `json:"formatted_address"`
+	AddressComponents []oneAddress  `json:"address_components"`
+	Address           string        `json:"formatted_address"`
+	Geometry          placeGeometry `json:"geometry"`
+	PlaceID           string        `json:"place_id"`
+	Types             []string      `json:"types"`
+}
+
+// administrativeTypes to get the administrative area types of the place, such as administrative_area_level_2.
+func administrativeTypes(types []string) []string {
+	var result []string
+	for _, one := range types {
+		if strings.HasPrefix(one, "administrative_area_level_") {
+			result = append(result, one)
+		}
+	}
+	return result
 }
 
 type placeDetailResponse struct {
@@ -84,100 +251,241 @@ func getString(addr []oneAddress, tp string, isShort bool) (string, bool) {
 	return "", false
 }
 
+// return the name of the first existed type and the type.
+func getFirstString(addr []oneAddress, tps []string, isShort bool) (string, string, bool) {
+	for _, tp := range tps {
+		if name, ok := getString(addr, tp, isShort); ok && len(name) > 0 {
+			return name, tp, true
+		}
+	}
+	return "", "", false
+}
+
+// cityResultTypes the result types of a city in reverse geocoding.
+var cityResultTypes = []string{"locality"}
+
 // getLocationWithLatLng to get location with lat lng.
+// types are the result types tried in order, such as locality and administrative_area_level_1.
+// The place information is parsed from the result, it is in lang.
+// Return the place information, the type matched, error
 // If no result, return ErrNoPlace.
-// If out of limitation, return ErrLimitation
-func requestLocationWithLatLng(lat, lng float32) (string, error) {
-	request := gorequest.New().Timeout(10 * time.Second)
-	request.Type("json")
-	url := fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?result_type=locality&key=%s&latlng=%f,%f", googleKey, lat, lng)
-
-	if resp, body, errs := request.Get(url).EndBytes(); len(errs) != 0 {
-		return "", errs[0]
-	} else if resp.StatusCode != 200 {
-		return "", fmt.Errorf("Response status: %d", resp.StatusCode)
-	} else {
-		var result latLngLocation
-		if err := json.Unmarshal(body, &result); err != nil {
-			return "", err
-		}
+// If out of limitation, return ErrLimitation.
+// Other failed statuses are returned as *StatusError.
+func requestLocationWithLatLng(ctx context.Context, lat, lng float32, lang string, types []string) (placeInfo, string, error) {
+	params := url.Values{}
+	params.Set("latlng", fmt.Sprintf("%f,%f", lat, lng))
+	params.Set("result_type", strings.Join(types, "|"))
+	if len(lang) > 0 {
+		params.Set("language", lang)
+	}
+
+	var result latLngLocation
+	if err := getJSON(ctx, "/maps/api/geocode/json", params, &result); err != nil {
+		return placeInfo{}, "", err
+	}
+
+	if err := result.err(); err != nil {
+		return placeInfo{}, "", err
+	}
+
+	best, level, ok := pickLatLngResult(result.Results, types)
+	if !ok {
+		return placeInfo{}, "", ErrNoPlace
+	}
+	return best.placeInfo(), level, nil
+}
+
+// requestLocationWithAddress to get the city of an address with Google Geocoding API.
+// The place information is parsed from the result, it is in lang.
+// If the best result is not a city, such as a street address, the city is requested with its location.
+// If no result, return ErrNoPlace.
+func requestLocationWithAddress(ctx context.Context, address, lang string) (placeInfo, error) {
+	params := url.Values{}
+	params.Set("address", address)
+	params.Set("language", lang)
+
+	var result latLngLocation
+	if err := getJSON(ctx, "/maps/api/geocode/json", params, &result); err != nil {
+		return placeInfo{}, err
+	}
+
+	if err := result.err(); err != nil {
+		return placeInfo{}, err
+	} else if len(result.Results) == 0 {
+		return placeInfo{}, ErrNoPlace
+	}
 
-		if result.Status == "ZERO_RESULTS" {
-			return "", ErrNoPlace
-		} else if result.Status == "OVER_QUERY_LIMIT" {
-			return "", ErrLimitation
-		} else if result.Status == "OK" {
-			return result.Results[0].PlaceID, nil
+	best := result.Results[0]
+	if best.isCity() {
+		return best.placeInfo(), nil
+	}
+
+	location := best.Geometry.Location
+	info, _, err := requestLocationWithLatLng(ctx, float32(location.Lat), float32(location.Lng), lang, cityResultTypes)
+	return info, err
+}
+
+// getAutocompleteParams to get the query parameters of Google Autocomplete.
+func getAutocompleteParams(input, lang, sessionToken string, opts AutocompleteOptions) (url.Values, error) {
+	if err := opts.check(); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("types", "(cities)")
+	params.Set("language", lang)
+	params.Set("input", input)
+	if len(sessionToken) > 0 {
+		params.Set("sessiontoken", sessionToken)
+	}
+
+	if opts.Location != nil {
+		params.Set("location", fmt.Sprintf("%f,%f", opts.Location.Lat, opts.Location.Lng))
+	}
+	if opts.Radius > 0 {
+		params.Set("radius", strconv.Itoa(opts.Radius))
+	}
+
+	if len(opts.Countries) > 0 {
+		components := make([]string, len(opts.Countries))
+		for i, one := range opts.Countries {
+			components[i] = "country:" + strings.ToLower(one)
 		}
+		params.Set("components", strings.Join(components, "|"))
+	}
 
-		return "", errors.New("Unhandled result.")
+	if len(opts.Region) > 0 {
+		params.Set("region", strings.ToLower(opts.Region))
 	}
+	if opts.StrictBounds {
+		params.Set("strictbounds", "true")
+	}
+	return params, nil
 }
 
 // getAutoComplete to get placeids and their description with input.
-// Return place ids, descriptions, error
-func requestAutoComplete(input, lang string) ([]string, []string, error) {
-	request := gorequest.New().Timeout(10 * time.Second)
-	request.Type("json")
-	url := fmt.Sprintf("https://maps.googleapis.com/maps/api/place/autocomplete/json?types=(cities)&language=%s&key=%s&input=%s", lang, googleKey, input)
-
-	if resp, body, errs := request.Get(url).EndBytes(); len(errs) != 0 {
-		return nil, nil, errs[0]
-	} else if resp.StatusCode != 200 {
-		return nil, nil, fmt.Errorf("Response status: %d", resp.StatusCode)
-	} else {
-		var result predictLocation
-		if err := json.Unmarshal(body, &result); err != nil {
-			return nil, nil, err
-		}
+// The request is a part of the autocomplete session if sessionToken is not empty.
+// Return place ids, descriptions, main texts, error
+func requestAutoComplete(ctx context.Context, input, lang, sessionToken string, opts AutocompleteOptions) ([]string, []string, []string, error) {
+	params, err := getAutocompleteParams(input, lang, sessionToken, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		if result.Status == "ZERO_RESULTS" {
-			return nil, nil, ErrNoPlace
-		} else if result.Status == "OVER_QUERY_LIMIT" {
-			return nil, nil, ErrLimitation
-		} else if result.Status == "OK" {
-			var placeids []string
-			var descriptions []string
-
-			for _, one := range result.Results {
-				placeids = append(placeids, one.PlaceID)
-				descriptions = append(descriptions, one.Description)
-			}
+	var result predictLocation
+	if err = getJSON(ctx, "/maps/api/place/autocomplete/json", params, &result); err != nil {
+		return nil, nil, nil, err
+	}
 
-			return placeids, descriptions, nil
-		}
+	if err = result.err(); err != nil {
+		return nil, nil, nil, err
+	}
 
-		return nil, nil, errors.New("Unhandled result.")
+	var placeids []string
+	var descriptions []string
+	var mainTexts []string
+
+	for _, one := range result.Results {
+		placeids = append(placeids, one.PlaceID)
+		descriptions = append(descriptions, one.Description)
+		mainTexts = append(mainTexts, one.mainText())
 	}
+
+	return placeids, descriptions, mainTexts, nil
+}
+
+// placeInfo to define the information of a place.
+type placeInfo struct {
+	// PlaceID may be different from the requested one if Google refreshed it.
+	// Name is the short name and LongName is the long name.
+	// NameType is the address component type of Name, such as locality.
+	PlaceID     string
+	Country     string
+	CountryName string
+	Name        string
+	LongName    string
+	NameType    string
+	Address     string
+	Lat         float64
+	Lng         float64
+
+	// RegionNames are the names of administrative_area_level_1 and administrative_area_level_2.
+	RegionNames [regionLevels]string
 }
 
 // getPlaceInfo to get place information with place ID.
-func requestPlaceInfo(placeid, lang string) (country, countryName, placeName, address string, erro error) {
-	request := gorequest.New().Timeout(10 * time.Second)
-	request.Type("json")
-	url := fmt.Sprintf("https://maps.googleapis.com/maps/api/place/details/json?placeid=%s&key=%s&language=%s", placeid, googleKey, lang)
-
-	if resp, body, errs := request.Get(url).EndBytes(); len(errs) != 0 {
-		erro = errs[0]
-	} else if resp.StatusCode != 200 {
-		erro = fmt.Errorf("Response status: %d", resp.StatusCode)
-	} else {
-		var result placeDetailResponse
-		if err := json.Unmarshal(body, &result); err != nil {
-			erro = err
-			return
-		}
+// If the place ID is obsolete, return *StatusError of ErrNotFound.
+// The request ends the autocomplete session if sessionToken is not empty.
+func requestPlaceInfo(ctx context.Context, placeid, lang, sessionToken string) (placeInfo, error) {
+	params := url.Values{}
+	params.Set("placeid", placeid)
+	params.Set("language", lang)
+	if len(sessionToken) > 0 {
+		params.Set("sessiontoken", sessionToken)
+	}
 
-		if result.Status == "ZERO_RESULTS" {
-			erro = ErrNoPlace
-		} else if result.Status == "OVER_QUERY_LIMIT" {
-			erro = errors.New("Request too many.")
-		} else if result.Status == "OK" {
-			country, _ = getString(result.Results.AddressComponents, "country", true)
-			countryName, _ = getString(result.Results.AddressComponents, "country", false)
-			placeName, _ = getString(result.Results.AddressComponents, "locality", true)
-			address = result.Results.Address
-		}
+	var result placeDetailResponse
+	if err := getJSON(ctx, "/maps/api/place/details/json", params, &result); err != nil {
+		return placeInfo{}, err
+	}
+
+	if err := result.err(); err != nil {
+		return placeInfo{}, err
+	}
+
+	info := getPlaceInfo(result.Results)
+	if len(info.PlaceID) == 0 {
+		info.PlaceID = placeid
+	}
+	return info, nil
+}
+
+// getPlaceInfo to get the place information of the result of Place Details or Geocoding.
+func getPlaceInfo(result placeDetailResult) placeInfo {
+	var info placeInfo
+	info.PlaceID = result.PlaceID
+	info.Country, _ = getString(result.AddressComponents, "country", true)
+	info.CountryName, _ = getString(result.AddressComponents, "country", false)
+	info.Name, info.NameType, _ = getFirstString(result.AddressComponents, nameTypes, true)
+	if len(info.NameType) == 0 {
+		// the place is a higher administrative level, such as a county from the reverse geocoding fallback
+		info.Name, info.NameType, _ = getFirstString(result.AddressComponents, administrativeTypes(result.Types), true)
+	}
+	if len(info.NameType) > 0 {
+		info.LongName, _ = getString(result.AddressComponents, info.NameType, false)
+	}
+	info.Address = result.Address
+	for i := range info.RegionNames {
+		info.RegionNames[i], _ = getString(result.AddressComponents, fmt.Sprintf("administrative_area_level_%d", i+1), false)
+	}
+	info.Lat = result.Geometry.Location.Lat
+	info.Lng = result.Geometry.Location.Lng
+	return info
+}
+
+type timezoneResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"errorMessage"`
+	TimeZoneID   string `json:"timeZoneId"`
+}
+
+// requestTimezone to get the IANA timezone of a location with Google Time Zone API.
+// If the location has no timezone, return ErrNoTimezone.
+func requestTimezone(ctx context.Context, lat, lng float64) (string, error) {
+	params := url.Values{}
+	params.Set("location", fmt.Sprintf("%f,%f", lat, lng))
+	params.Set("timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+
+	var result timezoneResponse
+	if err := getJSON(ctx, "/maps/api/timezone/json", params, &result); err != nil {
+		return "", err
+	}
+
+	// the time zone API names error_message in camel case, ZERO_RESULTS means no timezone such as at sea
+	if err := (statusField{Status: result.Status, ErrorMessage: result.ErrorMessage}).err(); errors.Is(err, ErrNoPlace) {
+		return "", ErrNoTimezone
+	} else if err != nil {
+		return "", err
 	}
-	return
+	return result.TimeZoneID, nil
 }
@@ -1,57 +1,365 @@
 package kkcity
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// xiamenComponents the address components of Xiamen in English.
+const xiamenComponents = `[
+	{"long_name":"Xiamen","short_name":"Xiamen","types":["locality","political"]},
+	{"long_name":"Fujian","short_name":"Fujian","types":["administrative_area_level_1","political"]},
+	{"long_name":"China","short_name":"CN","types":["country","political"]}]`
+
+// useTestGoogle to serve the Google APIs with the handler during the test.
+func useTestGoogle(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	key := googleKey
+
+	googleKey = "key1"
+	SetGoogleBaseURL(server.URL + "/")
+	SetHTTPClient(server.Client())
+	t.Cleanup(func() {
+		server.Close()
+		googleKey = key
+		SetGoogleBaseURL("")
+		SetHTTPClient(nil)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			assert.Equal(t, "key1", r.URL.Query().Get("key"), "Key should be set.")
+			assert.Equal(t, "a b", r.URL.Query().Get("q"), "Query is wrong.")
+			fmt.Fprint(w, `{"status":"OK"}`)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			fmt.Fprint(w, `{"status":"OK"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	var result statusField
+	assert.NoError(t, getJSON(context.Background(), "/ok", url.Values{"q": {"a b"}}, &result), "Should get the result.")
+	assert.Equal(t, "OK", result.Status, "Status is wrong.")
+
+	err := getJSON(context.Background(), "/fail", url.Values{}, &result)
+	assert.EqualError(t, err, "Response status: 500", "Should fail with the status.")
+
+	SetRequestTimeout(50 * time.Millisecond)
+	defer SetRequestTimeout(10 * time.Second)
+	assert.Error(t, getJSON(context.Background(), "/slow", url.Values{}, &result), "Should time out.")
+
+	// the request in flight is aborted when ctx is done
+	SetRequestTimeout(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.True(t, errors.Is(getJSON(ctx, "/slow", url.Values{}, &result), context.DeadlineExceeded), "Should be aborted.")
+}
+
 func TestRequestLocationWithLatLng(t *testing.T) {
-	placeid, err := requestLocationWithLatLng(24.54918, 118.12705)
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/maps/api/geocode/json", r.URL.Path, "Path is wrong.")
+		assert.Equal(t, "locality", query.Get("result_type"), "Result type is wrong.")
+		assert.Equal(t, "en", query.Get("language"), "Language is wrong.")
+
+		if query.Get("latlng") == "0.000000,0.000000" {
+			fmt.Fprint(w, `{"status":"ZERO_RESULTS","results":[]}`)
+			return
+		}
+		assert.Equal(t, "24.549179,118.127052", query.Get("latlng"), "Location is wrong.")
+		fmt.Fprintf(w, `{"status":"OK","results":[{"place_id":"ChIJJ-u_5XmDFDQRVtBolgpnoCg","types":["locality","political"],
+			"formatted_address":"Xiamen, Fujian, China","address_components":%s}]}`, xiamenComponents)
+	})
+
+	info, level, err := requestLocationWithLatLng(context.Background(), 24.54918, 118.12705, "en", cityResultTypes)
 	assert.NoError(t, err, "Should get the city information.")
-	assert.Equal(t, "ChIJJ-u_5XmDFDQRVtBolgpnoCg", placeid, "Place ID result is wrong.")
+	assert.Equal(t, "ChIJJ-u_5XmDFDQRVtBolgpnoCg", info.PlaceID, "Place ID result is wrong.")
+	assert.Equal(t, "Xiamen", info.Name, "Name is wrong.")
+	assert.Equal(t, "CN", info.Country, "Country is wrong.")
+	assert.Equal(t, "locality", level, "Level is wrong.")
 
-	_, err = requestLocationWithLatLng(0, 0)
+	_, _, err = requestLocationWithLatLng(context.Background(), 0, 0, "en", cityResultTypes)
 	assert.Equal(t, ErrNoPlace, err, "Should find no place.")
 }
 
+func TestPickLatLngResult(t *testing.T) {
+	results := []oneLatLngResult{
+		{PlaceID: "id1", Types: []string{"administrative_area_level_2", "political"}},
+		{PlaceID: "id2", Types: []string{"administrative_area_level_1", "political"}},
+	}
+
+	_, _, ok := pickLatLngResult(results, []string{"locality"})
+	assert.False(t, ok, "Should find no city.")
+
+	one, level, ok := pickLatLngResult(results, []string{"locality", "administrative_area_level_1", "administrative_area_level_2"})
+	assert.True(t, ok, "Should fall back to the administrative level.")
+	assert.Equal(t, "id2", one.PlaceID, "Result should be of the first type in order.")
+	assert.Equal(t, "administrative_area_level_1", level, "Level is wrong.")
+
+	assert.Equal(t, []string{"administrative_area_level_2"}, administrativeTypes(results[0].Types), "Administrative types are wrong.")
+}
+
+func TestLatLngResultIsCity(t *testing.T) {
+	var result latLngLocation
+	err := json.Unmarshal([]byte(`{"status":"OK","results":[
+		{"place_id":"id1","types":["street_address"],"geometry":{"location":{"lat":37.33,"lng":-122.03}}},
+		{"place_id":"id2","types":["locality","political"]}]}`), &result)
+	assert.NoError(t, err, "Should be able to parse result.")
+	assert.False(t, result.Results[0].isCity(), "Street address is not a city.")
+	assert.True(t, result.Results[1].isCity(), "Locality is a city.")
+	assert.Equal(t, 37.33, result.Results[0].Geometry.Location.Lat, "Location is wrong.")
+}
+
+func TestLatLngResultPlaceInfo(t *testing.T) {
+	var result latLngLocation
+	err := json.Unmarshal([]byte(`{"status":"OK","results":[{"place_id":"id1","types":["locality","political"],
+		"formatted_address":"Xiamen, Fujian, China","geometry":{"location":{"lat":24.48,"lng":118.09}},
+		"address_components":[
+			{"long_name":"Xiamen","short_name":"Xiamen","types":["locality","political"]},
+			{"long_name":"Fujian Province","short_name":"Fujian","types":["administrative_area_level_1","political"]},
+			{"long_name":"China","short_name":"CN","types":["country","political"]}]}]}`), &result)
+	assert.NoError(t, err, "Should be able to parse result.")
+
+	info := result.Results[0].placeInfo()
+	assert.Equal(t, "id1", info.PlaceID, "Place ID is wrong.")
+	assert.Equal(t, "Xiamen", info.Name, "Name is wrong.")
+	assert.Equal(t, "locality", info.NameType, "Name type is wrong.")
+	assert.Equal(t, "CN", info.Country, "Country is wrong.")
+	assert.Equal(t, "China", info.CountryName, "Country name is wrong.")
+	assert.Equal(t, "Xiamen, Fujian, China", info.Address, "Address is wrong.")
+	assert.Equal(t, "Fujian Province", info.RegionNames[0], "Region name is wrong.")
+	assert.Equal(t, 24.48, info.Lat, "Location is wrong.")
+}
+
+func TestRequestLocationWithAddress(t *testing.T) {
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if len(query.Get("address")) > 0 {
+			assert.Equal(t, "1 Siming South Road, Xiamen", query.Get("address"), "Address is wrong.")
+			fmt.Fprint(w, `{"status":"OK","results":[{"place_id":"id1","types":["street_address"],
+				"geometry":{"location":{"lat":24.44,"lng":118.09}}}]}`)
+			return
+		}
+
+		// the street address is not a city, the city is requested with its location
+		assert.Equal(t, "24.440001,118.089996", query.Get("latlng"), "Location is wrong.")
+		fmt.Fprintf(w, `{"status":"OK","results":[{"place_id":"ChIJJ-u_5XmDFDQRVtBolgpnoCg","types":["locality","political"],
+			"address_components":%s}]}`, xiamenComponents)
+	})
+
+	info, err := requestLocationWithAddress(context.Background(), "1 Siming South Road, Xiamen", "en")
+	assert.NoError(t, err, "Should get the city.")
+	assert.Equal(t, "ChIJJ-u_5XmDFDQRVtBolgpnoCg", info.PlaceID, "Place ID result is wrong.")
+}
+
+func TestGetAutocompleteParams(t *testing.T) {
+	params, err := getAutocompleteParams("San José & co", "en", "token1", AutocompleteOptions{})
+	assert.NoError(t, err, "Should be able to get params.")
+	assert.Equal(t, "San José & co", params.Get("input"), "Input is wrong.")
+	assert.Contains(t, params.Encode(), "input=San+Jos%C3%A9+%26+co", "Input should be encoded.")
+	assert.Equal(t, "token1", params.Get("sessiontoken"), "Session token is wrong.")
+	assert.Empty(t, params.Get("components"), "Components should not be set.")
+
+	opts := AutocompleteOptions{
+		Location:     &LatLng{Lat: 24.47, Lng: 118.08},
+		Radius:       50000,
+		Countries:    []string{"CN", "tw"},
+		Region:       "CN",
+		StrictBounds: true,
+	}
+	params, err = getAutocompleteParams("bao", "zh", "", opts)
+	assert.NoError(t, err, "Should be able to get params.")
+	assert.Equal(t, "24.470000,118.080000", params.Get("location"), "Location is wrong.")
+	assert.Equal(t, "50000", params.Get("radius"), "Radius is wrong.")
+	assert.Equal(t, "country:cn|country:tw", params.Get("components"), "Components are wrong.")
+	assert.Equal(t, "cn", params.Get("region"), "Region is wrong.")
+	assert.Equal(t, "true", params.Get("strictbounds"), "Strict bounds is wrong.")
+	assert.Empty(t, params.Get("sessiontoken"), "Session token should not be set.")
+
+	_, err = getAutocompleteParams("bao", "en", "", AutocompleteOptions{StrictBounds: true})
+	assert.Equal(t, ErrAutocompleteOptions, err, "Strict bounds needs location.")
+
+	_, err = getAutocompleteParams("bao", "en", "", AutocompleteOptions{Countries: []string{"CN", "US", "JP", "KR", "TW", "HK"}})
+	assert.Equal(t, ErrAutocompleteOptions, err, "Countries are too many.")
+
+	_, err = getAutocompleteParams("bao", "en", "", AutocompleteOptions{Countries: []string{"CHN"}})
+	assert.Equal(t, ErrCountryID, err, "Country ID should be wrong.")
+}
+
+func TestPredictMainText(t *testing.T) {
+	var result predictResult
+	err := json.Unmarshal([]byte(`{"description":"Baotou, Inner Mongolia, China","place_id":"id1",
+		"structured_formatting":{"main_text":"Baotou","secondary_text":"Inner Mongolia, China"},
+		"terms":[{"offset":0,"value":"Baotou"},{"offset":8,"value":"Inner Mongolia"}]}`), &result)
+	assert.NoError(t, err, "Should be able to parse prediction.")
+	assert.Equal(t, "Baotou", result.mainText(), "Main text is wrong.")
+
+	result.Formatting = predictFormatting{}
+	assert.Equal(t, "Baotou", result.mainText(), "First term should be used.")
+
+	result.Terms = nil
+	assert.Empty(t, result.mainText(), "There should be no main text.")
+}
+
 func TestRequestAutoComplete(t *testing.T) {
-	placeids, descriptions, err := requestAutoComplete("bao", "en")
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/maps/api/place/autocomplete/json", r.URL.Path, "Path is wrong.")
+		assert.Equal(t, "bao", query.Get("input"), "Input is wrong.")
+		assert.Equal(t, "(cities)", query.Get("types"), "Types are wrong.")
+		assert.Equal(t, "key1", query.Get("key"), "Key is wrong.")
+		fmt.Fprint(w, `{"status":"OK","predictions":[
+			{"place_id":"ChIJ2xkwFyJYBDYRfF3XPzwYEZo","description":"Baotou, Inner Mongolia, China",
+				"structured_formatting":{"main_text":"Baotou","secondary_text":"Inner Mongolia, China"}},
+			{"place_id":"ChIJlQRfqI2P5TURK0rev-lCO84","description":"Baoding, Hebei, China",
+				"terms":[{"value":"Baoding"},{"value":"Hebei"},{"value":"China"}]}]}`)
+	})
+
+	placeids, descriptions, mainTexts, err := requestAutoComplete(context.Background(), "bao", "en", "", AutocompleteOptions{})
 	assert.Nil(t, err, "Shoule be able to get auto complete result.")
 
-	assert.EqualValues(t, 5, len(placeids), "Should get max record.")
-	assert.EqualValues(t, 5, len(descriptions), "Should get max record.")
+	assert.EqualValues(t, 2, len(placeids), "Should get all the predictions.")
+	assert.EqualValues(t, 2, len(descriptions), "Should get all the predictions.")
+	assert.EqualValues(t, 2, len(mainTexts), "Should get all the predictions.")
 
 	// 0
 	assert.Equal(t, "Baotou, Inner Mongolia, China", descriptions[0], "Description result is wrong.")
 	assert.Equal(t, "ChIJ2xkwFyJYBDYRfF3XPzwYEZo", placeids[0], "Place ID result is wrong.")
+	assert.Equal(t, "Baotou", mainTexts[0], "Main text result is wrong.")
 
 	// 1
 	assert.Equal(t, "Baoding, Hebei, China", descriptions[1], "Description result is wrong.")
 	assert.Equal(t, "ChIJlQRfqI2P5TURK0rev-lCO84", placeids[1], "Place ID result is wrong.")
-
-	// 2
-	assert.Equal(t, "Baoji, Shaanxi, China", descriptions[2], "Description result is wrong.")
-	assert.Equal(t, "ChIJ6ZCbaAPdYDYRVyMhVjeyeOs", placeids[2], "Place ID result is wrong.")
-
-	// 3
-	assert.Equal(t, "Baoshan, Yunnan, China", descriptions[3], "Description result is wrong.")
-	assert.Equal(t, "ChIJkc6IxzA2LzcReDKMDHoyouE", placeids[3], "Place ID result is wrong.")
+	assert.Equal(t, "Baoding", mainTexts[1], "Main text result is wrong.")
 }
 
 func TestRequestPlaceInfo(t *testing.T) {
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/maps/api/place/details/json", r.URL.Path, "Path is wrong.")
+
+		switch query.Get("placeid") {
+		case "obsolete":
+			fmt.Fprint(w, `{"status":"NOT_FOUND"}`)
+		case "ChIJJ-u_5XmDFDQRVtBolgpnoCg":
+			if query.Get("language") == "zh" {
+				assert.Equal(t, "token1", query.Get("sessiontoken"), "Session token is wrong.")
+				fmt.Fprint(w, `{"status":"OK","result":{"formatted_address":"中国福建省厦门市","address_components":[
+					{"long_name":"厦门市","short_name":"厦门","types":["locality","political"]},
+					{"long_name":"福建省","short_name":"福建省","types":["administrative_area_level_1","political"]},
+					{"long_name":"中国","short_name":"CN","types":["country","political"]}]}}`)
+				return
+			}
+
+			assert.Empty(t, query.Get("sessiontoken"), "Session token should not be set.")
+			fmt.Fprintf(w, `{"status":"OK","result":{"place_id":"ChIJJ-u_5XmDFDQRVtBolgpnoCg","formatted_address":"Xiamen, Fujian, China",
+				"geometry":{"location":{"lat":24.479834,"lng":118.089425}},"address_components":%s}}`, xiamenComponents)
+		}
+	})
+
 	placeid := "ChIJJ-u_5XmDFDQRVtBolgpnoCg"
-	country, countryName, placeName, address, err := requestPlaceInfo(placeid, "en")
+	info, err := requestPlaceInfo(context.Background(), placeid, "en", "")
 	assert.Nil(t, err, "Should be able to get place information.")
-	assert.Equal(t, "CN", country, "Country information wrong.")
-	assert.Equal(t, "China", countryName, "Country name information wrong.")
-	assert.Equal(t, "Xiamen", placeName, "Place name information wrong.")
-	assert.Equal(t, "Xiamen, Fujian, China", address, "Address information wrong.")
+	assert.Equal(t, placeid, info.PlaceID, "Place ID information wrong.")
+	assert.Equal(t, "CN", info.Country, "Country information wrong.")
+	assert.Equal(t, "China", info.CountryName, "Country name information wrong.")
+	assert.Equal(t, "Xiamen", info.Name, "Place name information wrong.")
+	assert.Equal(t, "Xiamen", info.LongName, "Place long name information wrong.")
+	assert.Equal(t, "locality", info.NameType, "Place name type information wrong.")
+	assert.Equal(t, "Xiamen, Fujian, China", info.Address, "Address information wrong.")
+	assert.Equal(t, "Fujian", info.RegionNames[0], "Region information wrong.")
+	assert.InDelta(t, 24.47, info.Lat, 0.1, "Latitude information wrong.")
+	assert.InDelta(t, 118.08, info.Lng, 0.1, "Longitude information wrong.")
 
-	country, countryName, placeName, address, err = requestPlaceInfo(placeid, "zh")
+	info, err = requestPlaceInfo(context.Background(), placeid, "zh", "token1")
 	assert.Nil(t, err, "Should be able to get place information.")
-	assert.Equal(t, "CN", country, "Country information wrong.")
-	assert.Equal(t, "中国", countryName, "Country name information wrong.")
-	assert.Equal(t, "厦门", placeName, "Place name information wrong.")
-	assert.Equal(t, "中国福建省厦门市", address, "Address information wrong.")
+	assert.Equal(t, placeid, info.PlaceID, "Requested place ID should be used.")
+	assert.Equal(t, "CN", info.Country, "Country information wrong.")
+	assert.Equal(t, "中国", info.CountryName, "Country name information wrong.")
+	assert.Equal(t, "厦门", info.Name, "Place name information wrong.")
+	assert.Equal(t, "厦门市", info.LongName, "Place long name information wrong.")
+	assert.Equal(t, "中国福建省厦门市", info.Address, "Address information wrong.")
+	assert.Equal(t, "福建省", info.RegionNames[0], "Region information wrong.")
+
+	_, err = requestPlaceInfo(context.Background(), "obsolete", "en", "")
+	assert.True(t, errors.Is(err, ErrNotFound), "Obsolete place ID should be not found.")
+}
+
+func TestRequestTimezone(t *testing.T) {
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/maps/api/timezone/json", r.URL.Path, "Path is wrong.")
+		if r.URL.Query().Get("location") == "0.000000,-140.000000" {
+			fmt.Fprint(w, `{"status":"ZERO_RESULTS"}`)
+			return
+		}
+		assert.Equal(t, "24.470000,118.080000", r.URL.Query().Get("location"), "Location is wrong.")
+		assert.True(t, strings.HasPrefix(r.URL.Query().Get("timestamp"), "1"), "Timestamp is wrong.")
+		fmt.Fprint(w, `{"status":"OK","timeZoneId":"Asia/Shanghai"}`)
+	})
+
+	zone, err := requestTimezone(context.Background(), 24.47, 118.08)
+	assert.NoError(t, err, "Should be able to get timezone.")
+	assert.Equal(t, "Asia/Shanghai", zone, "Timezone is wrong.")
+
+	_, err = requestTimezone(context.Background(), 0, -140)
+	assert.Equal(t, ErrNoTimezone, err, "Location at sea should have no timezone.")
+}
+
+func TestStatusError(t *testing.T) {
+	assert.NoError(t, statusField{Status: "OK"}.err(), "OK should have no error.")
+	assert.Equal(t, ErrNoPlace, statusField{Status: "ZERO_RESULTS"}.err(), "Should find no place.")
+	assert.Equal(t, ErrLimitation, statusField{Status: "OVER_QUERY_LIMIT"}.err(), "Should be over limitation.")
+
+	err := statusField{Status: "OVER_DAILY_LIMIT", ErrorMessage: "Billing is not enabled."}.err()
+	assert.Contains(t, err.Error(), "Billing is not enabled.", "Message should be kept.")
+	assert.True(t, errors.Is(err, ErrRequestDenied), "Daily limit should be request denied.")
+	assert.False(t, errors.Is(err, ErrLimitation), "Daily limit should not be transient.")
+
+	err = statusField{Status: "REQUEST_DENIED", ErrorMessage: "The provided API key is invalid."}.err()
+	assert.True(t, errors.Is(err, ErrRequestDenied), "Should be request denied.")
+	assert.False(t, errors.Is(err, ErrUnknown), "Should not be unknown error.")
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr), "Should be a status error.")
+	assert.Equal(t, "REQUEST_DENIED", statusErr.Status, "Status is wrong.")
+	assert.Equal(t, "The provided API key is invalid.", statusErr.Message, "Message is wrong.")
+
+	assert.True(t, errors.Is(statusField{Status: "INVALID_REQUEST"}.err(), ErrInvalidRequest), "Should be invalid request.")
+	assert.True(t, errors.Is(statusField{Status: "NOT_FOUND"}.err(), ErrNotFound), "Should be not found.")
+	assert.True(t, errors.Is(statusField{Status: "UNKNOWN_ERROR"}.err(), ErrUnknown), "Should be unknown error.")
+
+	err = statusField{Status: "SOMETHING_NEW"}.err()
+	assert.True(t, errors.As(err, &statusErr), "Should be a status error.")
+	assert.Equal(t, "SOMETHING_NEW", statusErr.Status, "Status is wrong.")
+}
+
+func TestGetFirstString(t *testing.T) {
+	addr := []oneAddress{
+		{LongName: "Hackney", ShortName: "Hackney", Types: []string{"administrative_area_level_3", "political"}},
+		{LongName: "London", ShortName: "London", Types: []string{"postal_town"}},
+		{LongName: "United Kingdom", ShortName: "GB", Types: []string{"country", "political"}},
+	}
+
+	name, tp, ok := getFirstString(addr, nameTypes, true)
+	assert.True(t, ok, "Should find the name.")
+	assert.Equal(t, "London", name, "Name is wrong.")
+	assert.Equal(t, "postal_town", tp, "Name type is wrong.")
+
+	_, _, ok = getFirstString(addr, []string{"locality"}, true)
+	assert.False(t, ok, "Should not find the name.")
 }
@@ -0,0 +1,182 @@
+package kkcity
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// CityResult to define the result of one city in a list.
+type CityResult struct {
+	// Rank is the position in the list, the results of a stream arrive in the order they are resolved.
+	Rank int
+
+	PlaceID string
+	Name    string
+	Address string
+
+	// Timezone is the recorded IANA timezone of the city, such as Asia/Shanghai, empty if not resolved.
+	Timezone string
+
+	// Err is the error of the city, the other fields except PlaceID are empty if it is not nil.
+	Err error
+}
+
+// concurrency the max count of the cities requested from Google at the same time.
+var concurrency = 4
+
+// SetConcurrency to set the max count of the cities requested from Google at the same time, 4 by default.
+func SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	concurrency = n
+}
+
+// isFatalError to check whether the error stops requesting the remaining cities.
+// The errors of a certain place, such as ErrNotFound, only fail the city.
+func isFatalError(err error) bool {
+	switch {
+	case errors.Is(err, ErrNoPlace), errors.Is(err, ErrNotFound),
+		errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrUnknown):
+		return false
+	}
+	return true
+}
+
+// resolveCities to get the cities of the placeids with bounded concurrency.
+// The first fatal error cancels the cities not started yet, their results carry context.Canceled.
+// The results are in the order of the placeids.
+// Return results, the fatal error or the error of ctx
+func resolveCities(ctx context.Context, placeIDs []string, lang string, policy NamePolicy) ([]CityResult, error) {
+	results := make([]CityResult, len(placeIDs))
+	err := resolveCitiesFunc(ctx, placeIDs, lang, policy, func(result CityResult) {
+		results[result.Rank] = result
+	})
+	return results, err
+}
+
+// resolveCitiesFunc to get the cities of the placeids with bounded concurrency,
+// emit is called with each result as soon as it is resolved, maybe at the same time.
+// The Rank of the result is the index of its placeid.
+// Return the fatal error or the error of ctx
+func resolveCitiesFunc(ctx context.Context, placeIDs []string, lang string, policy NamePolicy, emit func(CityResult)) error {
+	return resolveEach(ctx, placeIDs, func(ctx context.Context, placeid string) (string, string, string, error) {
+		return handleCityInfo(ctx, placeid, lang, policy)
+	}, emit)
+}
+
+// resolveEach to resolve the keys to cities with bounded concurrency,
+// emit is called with each result as soon as it is resolved, maybe at the same time.
+// The Rank of the result is the index of its key, PlaceID is the key if it failed.
+// The first fatal error cancels the keys not started yet, their results carry context.Canceled.
+// resolve is called with the ctx cancelled by it, so the Google requests in flight are aborted too.
+// Return the fatal error or the error of ctx
+func resolveEach(ctx context.Context, keys []string, resolve func(ctx context.Context, key string) (string, string, string, error), emit func(CityResult)) error {
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var fatal error
+	var fatalOnce sync.Once
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, key := range keys {
+		select {
+		case <-workCtx.Done():
+			emit(CityResult{Rank: i, PlaceID: key, Err: workCtx.Err()})
+			continue
+		case sem <- struct{}{}:
+		}
+
+		// the work may be cancelled while waiting
+		if err := workCtx.Err(); err != nil {
+			<-sem
+			emit(CityResult{Rank: i, PlaceID: key, Err: err})
+			continue
+		}
+
+		wg.Add(1)
+		go func(index int, thisKey string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			placeid, name, address, err := resolve(workCtx, thisKey)
+			if err != nil {
+				if isFatalError(err) {
+					fatalOnce.Do(func() {
+						fatal = err
+						cancel()
+					})
+				}
+				emit(CityResult{Rank: index, PlaceID: thisKey, Err: err})
+				return
+			}
+			emit(CityResult{Rank: index, PlaceID: placeid, Name: name, Address: address})
+		}(i, key)
+	}
+	wg.Wait()
+
+	if fatal != nil {
+		return fatal
+	}
+	return ctx.Err()
+}
+
+// fillCityTimezones to fill the recorded timezones of the cities resolved.
+func fillCityTimezones(results []CityResult) error {
+	var placeIDs []string
+	for _, one := range results {
+		if one.Err == nil {
+			placeIDs = append(placeIDs, one.PlaceID)
+		}
+	}
+
+	zones, err := getCityTimezones(placeIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range results {
+		results[i].Timezone = zones[results[i].PlaceID]
+	}
+	return nil
+}
+
+// withCityTimezone to fill the recorded timezone of the city resolved, the result carries the error if it failed.
+func withCityTimezone(result CityResult) CityResult {
+	if result.Err != nil {
+		return result
+	}
+
+	_, zone, err := getCityTimezone(result.PlaceID)
+	if err != nil {
+		return CityResult{Rank: result.Rank, PlaceID: result.PlaceID, Err: err}
+	}
+	result.Timezone = zone
+	return result
+}
+
+// splitCityResults to split the results into place ids, names, addresses,
+// the fatal error is returned, otherwise the first error of the cities.
+func splitCityResults(results []CityResult, err error) ([]string, []string, []string, error) {
+	if len(results) == 0 {
+		return nil, nil, nil, err
+	}
+
+	placeIDs := make([]string, len(results))
+	cityNames := make([]string, len(results))
+	cityAddresses := make([]string, len(results))
+
+	for i, one := range results {
+		placeIDs[i] = one.PlaceID
+		cityNames[i] = one.Name
+		cityAddresses[i] = one.Address
+		if err == nil {
+			err = one.Err
+		}
+	}
+	return placeIDs, cityNames, cityAddresses, err
+}
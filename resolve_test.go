@@ -0,0 +1,137 @@
+package kkcity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFatalError(t *testing.T) {
+	assert.False(t, isFatalError(ErrNoPlace), "No place only fails the city.")
+	assert.False(t, isFatalError(&StatusError{Status: "NOT_FOUND", err: ErrNotFound}), "Not found only fails the city.")
+	assert.True(t, isFatalError(ErrLimitation), "Limitation should be fatal.")
+	assert.True(t, isFatalError(&StatusError{Status: "REQUEST_DENIED", err: ErrRequestDenied}), "Request denied should be fatal.")
+	assert.True(t, isFatalError(errors.New("connection refused")), "Other error should be fatal.")
+}
+
+func TestResolveCitiesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := resolveCities(ctx, []string{"placeid1", "placeid2"}, "en", NameDefault)
+	assert.Equal(t, context.Canceled, err, "Should be cancelled.")
+	assert.Equal(t, 2, len(results), "Each placeid should have result.")
+	for _, one := range results {
+		assert.Equal(t, context.Canceled, one.Err, "City should be cancelled.")
+	}
+	assert.Equal(t, "placeid2", results[1].PlaceID, "Results should be in order.")
+}
+
+// collectResults to collect the emitted results by Rank.
+func collectResults(n int) ([]CityResult, func(CityResult)) {
+	var mu sync.Mutex
+	results := make([]CityResult, n)
+	return results, func(result CityResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[result.Rank] = result
+	}
+}
+
+func TestResolveEachConcurrency(t *testing.T) {
+	SetConcurrency(2)
+	defer SetConcurrency(4)
+
+	var inFlight, maxInFlight int32
+	keys := []string{"key0", "key1", "key2", "key3", "key4", "key5"}
+	results, emit := collectResults(len(keys))
+	err := resolveEach(context.Background(), keys, func(ctx context.Context, key string) (string, string, string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return "placeid" + key, "name" + key, "", nil
+	}, emit)
+
+	assert.NoError(t, err, "Should resolve all the keys.")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight), "Concurrency should be bounded.")
+	for i, one := range results {
+		assert.Equal(t, i, one.Rank, "Rank is wrong.")
+		assert.Equal(t, "placeid"+keys[i], one.PlaceID, "Result should be of its key.")
+	}
+}
+
+func TestResolveEachErrors(t *testing.T) {
+	// the errors of a certain place don't cancel the others
+	keys := []string{"key0", "key1", "key2"}
+	results, emit := collectResults(len(keys))
+	err := resolveEach(context.Background(), keys, func(ctx context.Context, key string) (string, string, string, error) {
+		if key == "key1" {
+			return "", "", "", ErrNoPlace
+		}
+		return "placeid" + key, "name" + key, "", nil
+	}, emit)
+
+	assert.NoError(t, err, "Error of a place should not be fatal.")
+	assert.Equal(t, "namekey0", results[0].Name, "City should be resolved.")
+	assert.Equal(t, ErrNoPlace, results[1].Err, "City should carry its error.")
+	assert.Equal(t, "key1", results[1].PlaceID, "Failed city should carry its key.")
+	assert.Equal(t, "namekey2", results[2].Name, "City after the failed one should be resolved.")
+
+	// the first fatal error cancels the keys not started yet
+	SetConcurrency(1)
+	defer SetConcurrency(4)
+
+	var calls int32
+	keys = []string{"key0", "key1", "key2", "key3"}
+	results, emit = collectResults(len(keys))
+	err = resolveEach(context.Background(), keys, func(ctx context.Context, key string) (string, string, string, error) {
+		atomic.AddInt32(&calls, 1)
+		if key == "key1" {
+			return "", "", "", ErrLimitation
+		}
+		return "placeid" + key, "name" + key, "", nil
+	}, emit)
+
+	assert.Equal(t, ErrLimitation, err, "Fatal error should be returned.")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "Keys after the fatal error should not be resolved.")
+	assert.NoError(t, results[0].Err, "City before the fatal error should be resolved.")
+	assert.Equal(t, ErrLimitation, results[1].Err, "City should carry the fatal error.")
+	for _, one := range results[2:] {
+		assert.Equal(t, context.Canceled, one.Err, "Remaining cities should be cancelled.")
+	}
+}
+
+func TestSplitCityResults(t *testing.T) {
+	results := []CityResult{
+		{PlaceID: "placeid1", Name: "Xiamen", Address: "Xiamen, Fujian, China"},
+		{PlaceID: "placeid2", Err: ErrNoPlace},
+		{PlaceID: "placeid3", Err: ErrUnknown},
+	}
+
+	placeIDs, names, addresses, err := splitCityResults(results, nil)
+	assert.Equal(t, []string{"placeid1", "placeid2", "placeid3"}, placeIDs, "Place ids are wrong.")
+	assert.Equal(t, []string{"Xiamen", "", ""}, names, "Names are wrong.")
+	assert.Equal(t, "Xiamen, Fujian, China", addresses[0], "Address is wrong.")
+	assert.Equal(t, ErrNoPlace, err, "The first error should be returned.")
+
+	fatal := fmt.Errorf("fatal")
+	_, _, _, err = splitCityResults(results, fatal)
+	assert.Equal(t, fatal, err, "The fatal error should be returned.")
+
+	placeIDs, _, _, err = splitCityResults(nil, ErrLimitation)
+	assert.Nil(t, placeIDs, "There should be no result.")
+	assert.Equal(t, ErrLimitation, err, "Error is wrong.")
+}
@@ -0,0 +1,90 @@
+package kkcity
+
+import "context"
+
+// SetCityResultTypes to set the result types of a city tried in order by reverse geocoding, locality by default.
+func SetCityResultTypes(types []string) {
+	cityResultTypes = append([]string(nil), types...)
+}
+
+// ReverseOptions to define how the place of a location is detected.
+type ReverseOptions struct {
+	// ResultTypes are the result types of a city tried in order, the ones set by SetCityResultTypes by default.
+	ResultTypes []string
+
+	// Fallback are the higher administrative levels tried in order if there is no city,
+	// such as administrative_area_level_2 and administrative_area_level_1 in rural areas or at sea near the coast.
+	// No fallback if it is empty.
+	Fallback []string
+
+	Policy NamePolicy
+}
+
+// ReverseResult to define the place of a location.
+type ReverseResult struct {
+	PlaceID string
+	Name    string
+	Address string
+
+	// Timezone is the recorded IANA timezone of the city, such as Asia/Shanghai,
+	// empty if not resolved or the result is a higher administrative level.
+	Timezone string
+
+	// Level is the result type matched, such as locality or administrative_area_level_1.
+	Level string
+}
+
+// GetCityWithLatLngOptions to get the city of a location, or the higher administrative level if there is no city.
+// The city is recorded, the higher administrative level is returned without being recorded
+// so it is not listed or searched as a city, its name is of the level matched.
+func GetCityWithLatLngOptions(lat, lng float32, langIndex int, opts ReverseOptions) (ReverseResult, error) {
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return ReverseResult{}, err
+	}
+
+	cityTypes := opts.ResultTypes
+	if len(cityTypes) == 0 {
+		cityTypes = cityResultTypes
+	}
+	types := append(append([]string(nil), cityTypes...), opts.Fallback...)
+
+	info, level, err := requestLocationWithLatLng(context.Background(), lat, lng, lang, types)
+	if err != nil {
+		return ReverseResult{}, err
+	}
+
+	if !isCityLevel(level, cityTypes) {
+		return getFallbackResult(info, level, opts.Policy), nil
+	}
+
+	result := ReverseResult{Level: level}
+	result.PlaceID, result.Name, result.Address, err = handleGeocodedCity(context.Background(), info, lang, opts.Policy)
+	if err != nil {
+		return ReverseResult{}, err
+	}
+
+	if _, result.Timezone, err = getCityTimezone(result.PlaceID); err != nil {
+		return ReverseResult{}, err
+	}
+	return result, nil
+}
+
+// isCityLevel to check whether the level matched is one of the city result types.
+func isCityLevel(level string, cityTypes []string) bool {
+	for _, one := range cityTypes {
+		if one == level {
+			return true
+		}
+	}
+	return false
+}
+
+// getFallbackResult to get the result of a higher administrative level from the geocoded information.
+func getFallbackResult(info placeInfo, level string, policy NamePolicy) ReverseResult {
+	result := ReverseResult{PlaceID: info.PlaceID, Name: info.Name, Address: info.Address, Level: level}
+	if policy == NameLong {
+		result.Name = info.LongName
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package kkcity
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCityLevel(t *testing.T) {
+	assert.True(t, isCityLevel("locality", cityResultTypes), "Locality should be a city.")
+	assert.False(t, isCityLevel("administrative_area_level_1", cityResultTypes), "Province should not be a city.")
+}
+
+func TestGetCityWithLatLngFallback(t *testing.T) {
+	setupLanguage(testLangs)
+	useTestGoogle(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "locality|administrative_area_level_2|administrative_area_level_1", r.URL.Query().Get("result_type"), "Result types are wrong.")
+		fmt.Fprint(w, `{"status":"OK","results":[{"place_id":"id1","types":["administrative_area_level_1","political"],
+			"formatted_address":"Fujian, China","address_components":[
+			{"long_name":"Fujian Province","short_name":"Fujian","types":["administrative_area_level_1","political"]},
+			{"long_name":"China","short_name":"CN","types":["country","political"]}]}]}`)
+	})
+
+	// the province is returned without being recorded, so the database is not used
+	opts := ReverseOptions{Fallback: []string{"administrative_area_level_2", "administrative_area_level_1"}, Policy: NameLong}
+	result, err := GetCityWithLatLngOptions(25.5, 119.5, 0, opts)
+	assert.NoError(t, err, "Should get the province.")
+	assert.Equal(t, ReverseResult{PlaceID: "id1", Name: "Fujian Province", Address: "Fujian, China", Level: "administrative_area_level_1"}, result, "Result is wrong.")
+}
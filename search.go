@@ -0,0 +1,309 @@
+package kkcity
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// SearchMode to define where the cities are searched.
+type SearchMode int
+
+const (
+	// SearchGoogle always requests Google Autocomplete, the same as GetCitiesWithInput.
+	SearchGoogle SearchMode = iota
+
+	// SearchLocal only searches the recorded cities.
+	SearchLocal
+
+	// SearchHybrid searches the recorded cities first,
+	// and requests Google Autocomplete only if the local results are fewer than the threshold.
+	SearchHybrid
+)
+
+// defaultSearchLimit the max count of the local results if it is not set.
+const defaultSearchLimit = 5
+
+// SearchOptions to define how the cities are searched.
+type SearchOptions struct {
+	Mode SearchMode
+
+	// Limit is the max count of the local results, 5 by default.
+	Limit int
+
+	// Threshold is used by SearchHybrid, Google is requested if the local results are fewer than it.
+	// It is Limit by default.
+	Threshold int
+
+	Policy NamePolicy
+
+	// SessionToken is the token of the autocomplete session from NewSessionToken, it is optional.
+	// The search is Fast if it is set, the session ends with the Place Details request of the city picked
+	// by GetCityWithSession, so the predictions are not requested one by one.
+	SessionToken string
+
+	// Autocomplete is the options of the Google Autocomplete request.
+	Autocomplete AutocompleteOptions
+
+	// Fast returns the names of the predictions from Google Autocomplete without Place Details requests.
+	// The name is the main text of the prediction, such as Xiamen, if the city is not recorded.
+	// The city is recorded when it is looked up with GetCityWithPlaceID or GetCityWithSession.
+	Fast bool
+
+	// EnrichInBackground requests the cities not recorded in background when Fast is set,
+	// so they are recorded without blocking the search. They are requested one by one,
+	// a city already waiting is not requested again, the errors go to SetEnrichErrorHandler.
+	EnrichInBackground bool
+}
+
+// isFast to check whether the predictions are returned without Place Details requests.
+func (opts SearchOptions) isFast() bool {
+	return opts.Fast || len(opts.SessionToken) > 0
+}
+
+// maxAutocompleteCountries the max count of the countries Google Autocomplete can be restricted to.
+const maxAutocompleteCountries = 5
+
+// ErrAutocompleteOptions to define the autocomplete options are wrong.
+var ErrAutocompleteOptions = errors.New("Autocomplete options are wrong.")
+
+// AutocompleteOptions to define the options of Google Autocomplete.
+type AutocompleteOptions struct {
+	// Location biases the predictions around it, Radius is the distance in meters.
+	Location *LatLng
+	Radius   int
+
+	// Countries restricts the predictions to at most 5 countries, such as CN.
+	Countries []string
+
+	// Region biases the predictions to the region, a ccTLD code such as cn.
+	Region string
+
+	// StrictBounds returns only the predictions inside Location and Radius.
+	StrictBounds bool
+}
+
+// check to check whether the options are valid.
+func (opts AutocompleteOptions) check() error {
+	if opts.Radius < 0 || len(opts.Countries) > maxAutocompleteCountries {
+		return ErrAutocompleteOptions
+	} else if opts.StrictBounds && (opts.Location == nil || opts.Radius == 0) {
+		return ErrAutocompleteOptions
+	}
+
+	for _, one := range opts.Countries {
+		if err := checkCountryID(one); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchCities to search cities with input.
+// The recorded cities are matched by the prefix of any word or by similarity of the names and addresses
+// in all the languages, case and accent insensitive.
+// Return place ids, city names, addresses, error
+func SearchCities(input string, langIndex int, opts SearchOptions) ([]string, []string, []string, error) {
+	return splitCityResults(SearchCityResults(context.Background(), input, langIndex, opts))
+}
+
+// SearchCityResults to search cities with input like SearchCities, each result carries its own error and timezone.
+// The error returned is fatal, such as ErrLimitation or ctx is done, the remaining cities are not requested
+// and their results carry the error. The results are still returned for partial success.
+func SearchCityResults(ctx context.Context, input string, langIndex int, opts SearchOptions) ([]CityResult, error) {
+	if err := checkSessionToken(opts.SessionToken); err != nil {
+		return nil, err
+	}
+
+	if err := opts.Autocomplete.check(); err != nil {
+		return nil, err
+	}
+
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := searchCityResults(ctx, strings.TrimSpace(input), lang, opts)
+	if zoneErr := fillCityTimezones(results); zoneErr != nil {
+		return nil, zoneErr
+	}
+	return results, err
+}
+
+// searchCityResults to search cities with input of the language.
+func searchCityResults(ctx context.Context, input, lang string, opts SearchOptions) ([]CityResult, error) {
+	if opts.Mode == SearchGoogle {
+		return getCityResultsWithInput(ctx, input, lang, opts)
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = defaultSearchLimit
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = opts.Limit
+	}
+
+	results, err := searchLocalCities(ctx, input, lang, opts.Policy, opts.Limit)
+	for i := range results {
+		results[i].Rank = i
+	}
+	if err != nil || opts.Mode == SearchLocal || len(results) >= opts.Threshold {
+		return results, err
+	}
+
+	googleResults, err := getCityResultsWithInput(ctx, input, lang, opts)
+	for _, one := range googleResults {
+		if !containsCityResult(results, one.PlaceID) {
+			one.Rank = len(results)
+			results = append(results, one)
+		}
+	}
+	return results, err
+}
+
+// StreamCityResults to search cities with input like SearchCityResults,
+// each city is sent to the channel as soon as it is resolved, tagged with its Rank in the list.
+// The recorded cities of SearchLocal and SearchHybrid are sent first.
+// The channel is closed when all the cities are sent or ctx is done.
+// The error returned is of the autocomplete request, the errors of the cities are carried by the results.
+func StreamCityResults(ctx context.Context, input string, langIndex int, opts SearchOptions) (<-chan CityResult, error) {
+	if err := checkSessionToken(opts.SessionToken); err != nil {
+		return nil, err
+	}
+
+	if err := opts.Autocomplete.check(); err != nil {
+		return nil, err
+	}
+
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	input = strings.TrimSpace(input)
+	if opts.Limit <= 0 {
+		opts.Limit = defaultSearchLimit
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = opts.Limit
+	}
+
+	// the recorded cities are fast, they are searched before streaming
+	var local []CityResult
+	if opts.Mode != SearchGoogle {
+		if local, err = searchLocalCities(ctx, input, lang, opts.Policy, opts.Limit); err != nil {
+			return nil, err
+		}
+	}
+
+	var placeIDs, descriptions, mainTexts []string
+	if opts.Mode == SearchGoogle || (opts.Mode == SearchHybrid && len(local) < opts.Threshold) {
+		if placeIDs, descriptions, mainTexts, err = requestAutoComplete(ctx, input, lang, opts.SessionToken, opts.Autocomplete); err != nil {
+			return nil, err
+		}
+	}
+
+	// the predictions already in the local results are skipped
+	var ids, idDescriptions, idMainTexts []string
+	for i, one := range placeIDs {
+		if !containsCityResult(local, one) {
+			ids = append(ids, one)
+			idDescriptions = append(idDescriptions, descriptions[i])
+			idMainTexts = append(idMainTexts, mainTexts[i])
+		}
+	}
+
+	var predictions []CityResult
+	if opts.isFast() {
+		if predictions, err = getPredictionResults(ids, idDescriptions, idMainTexts, lang, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(chan CityResult)
+	go func() {
+		defer close(results)
+
+		send := func(result CityResult) {
+			result = withCityTimezone(result)
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		}
+
+		for i, one := range local {
+			one.Rank = i
+			send(one)
+		}
+
+		for _, one := range predictions {
+			one.Rank += len(local)
+			send(one)
+		}
+
+		if opts.isFast() {
+			return
+		}
+
+		resolveCitiesFunc(ctx, ids, lang, opts.Policy, func(result CityResult) {
+			if result.Err == nil {
+				result.Address = idDescriptions[result.Rank]
+			}
+			result.Rank += len(local)
+			send(result)
+		})
+	}()
+	return results, nil
+}
+
+// searchLocalCities to search the recorded cities.
+// The cities without name of the language are requested from Google.
+func searchLocalCities(ctx context.Context, input, lang string, policy NamePolicy, limit int) ([]CityResult, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	placeIDs, cityNames, cityAddresses, err := searchCities(input, lang, policy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CityResult, len(placeIDs))
+	var unnamed []string
+	for i, one := range placeIDs {
+		results[i] = CityResult{PlaceID: one, Name: cityNames[i], Address: cityAddresses[i]}
+		if len(cityNames[i]) == 0 {
+			unnamed = append(unnamed, one)
+		}
+	}
+
+	if len(unnamed) == 0 {
+		return results, nil
+	}
+
+	resolved, err := resolveCities(ctx, unnamed, lang, policy)
+	for i, j := 0, 0; i < len(results) && j < len(resolved); i++ {
+		if results[i].PlaceID == unnamed[j] {
+			results[i] = resolved[j]
+			j++
+		}
+	}
+	return results, err
+}
+
+// containsCityResult to check whether the city is in the results.
+func containsCityResult(results []CityResult, placeid string) bool {
+	for _, one := range results {
+		if one.PlaceID == placeid {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeLike to escape the wildcards of LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,43 @@
+package kkcity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `100\% \_a\\b`, escapeLike(`100% _a\b`), "Wildcards should be escaped.")
+	assert.Equal(t, "Xiamen", escapeLike("Xiamen"), "Text should be the same.")
+}
+
+func TestContainsCityResult(t *testing.T) {
+	results := []CityResult{{PlaceID: "a"}, {PlaceID: "b"}}
+	assert.True(t, containsCityResult(results, "b"), "Should contain.")
+	assert.False(t, containsCityResult(results, "c"), "Should not contain.")
+	assert.False(t, containsCityResult(nil, "a"), "Should not contain.")
+}
+
+func TestSearchOptionsIsFast(t *testing.T) {
+	assert.False(t, SearchOptions{}.isFast(), "Search should not be fast.")
+	assert.True(t, SearchOptions{Fast: true}.isFast(), "Search should be fast.")
+	assert.True(t, SearchOptions{SessionToken: NewSessionToken()}.isFast(), "Search of a session should be fast.")
+}
+
+func TestStreamCityResults(t *testing.T) {
+	setupLanguage(testLangs)
+
+	_, err := StreamCityResults(context.Background(), "bao", 0, SearchOptions{SessionToken: "a&b"})
+	assert.Equal(t, ErrSessionToken, err, "Session token should be wrong.")
+
+	// nothing is searched with an empty input
+	results, err := StreamCityResults(context.Background(), " ", 0, SearchOptions{Mode: SearchLocal})
+	assert.NoError(t, err, "Should be able to stream.")
+
+	count := 0
+	for range results {
+		count++
+	}
+	assert.Equal(t, 0, count, "There should be no result.")
+}
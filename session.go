@@ -0,0 +1,71 @@
+package kkcity
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrSessionToken to define the session token is wrong.
+var ErrSessionToken = errors.New("Session token is wrong.")
+
+// maxSessionTokenLength the max length of the session token accepted by Google.
+const maxSessionTokenLength = 36
+
+// NewSessionToken to start an autocomplete session, the token is a UUID v4.
+// Pass the token to SearchCities while the user is typing, then to GetCityWithSession when a city is picked.
+// Google bills the autocomplete requests and the Place Details request of the session as one session.
+func NewSessionToken() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// checkSessionToken to check whether the session token is URL safe base64 within the max length.
+// An empty token means no session.
+func checkSessionToken(token string) error {
+	if len(token) > maxSessionTokenLength {
+		return ErrSessionToken
+	}
+
+	for _, c := range token {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return ErrSessionToken
+		}
+	}
+	return nil
+}
+
+// GetCityWithSession to get the city picked from the predictions of an autocomplete session, and end the session.
+// If the city is already recorded, no request is sent and Google bills the session by the autocomplete requests.
+// Return current placeid, name, address, error
+func GetCityWithSession(placeid, sessionToken string, langIndex int, policy ...NamePolicy) (string, string, string, error) {
+	if err := checkSessionToken(sessionToken); err != nil {
+		return "", "", "", err
+	}
+
+	lang, err := getLanguage(langIndex)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if placeid, err = getCurrentPlaceID(placeid); err != nil {
+		return "", "", "", err
+	}
+
+	cityExist, cityName, cityAddress, err := getCityInfo(placeid, lang, getNamePolicy(policy))
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if !cityExist || len(cityName) == 0 {
+		return fetchCityInfoWithSession(context.Background(), placeid, sessionToken, lang, getNamePolicy(policy), cityExist)
+	}
+	return placeid, cityName, cityAddress, nil
+}
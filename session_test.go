@@ -0,0 +1,23 @@
+package kkcity
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSessionToken(t *testing.T) {
+	token := NewSessionToken()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), token, "Token should be a UUID v4.")
+	assert.NoError(t, checkSessionToken(token), "Token should be valid.")
+	assert.NotEqual(t, token, NewSessionToken(), "Tokens should be different.")
+}
+
+func TestCheckSessionToken(t *testing.T) {
+	assert.NoError(t, checkSessionToken(""), "Empty token means no session.")
+	assert.NoError(t, checkSessionToken("abc_DEF-123"), "Token should be valid.")
+	assert.Equal(t, ErrSessionToken, checkSessionToken("abc&key=1"), "Token should be wrong.")
+	assert.Equal(t, ErrSessionToken, checkSessionToken(strings.Repeat("a", 37)), "Token should be too long.")
+}
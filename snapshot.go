@@ -0,0 +1,333 @@
+package kkcity
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Format to define the format of the snapshot.
+type Format int
+
+const (
+	// FormatNDJSON is one JSON record per line.
+	FormatNDJSON Format = iota
+
+	// FormatCSV is one row per record and language, with a header row.
+	FormatCSV
+)
+
+// ErrFormat to define the snapshot format is wrong.
+var ErrFormat = errors.New("Snapshot format is wrong.")
+
+// CityName to define the names of a city in one language.
+type CityName struct {
+	Name     string `json:"name,omitempty"`
+	LongName string `json:"long_name,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+// CityRecord to define a city in the snapshot.
+// Names is keyed by the language, such as en.
+type CityRecord struct {
+	PlaceID   string              `json:"placeid"`
+	CountryID string              `json:"country_id"`
+	Lat       *float64            `json:"lat,omitempty"`
+	Lng       *float64            `json:"lng,omitempty"`
+	Timezone  string              `json:"timezone,omitempty"`
+	NameType  string              `json:"name_type,omitempty"`
+	Names     map[string]CityName `json:"names"`
+}
+
+// CountryName to define the names of a country in one language.
+type CountryName struct {
+	Name      string `json:"name,omitempty"`
+	ShortName string `json:"short_name,omitempty"`
+}
+
+// CountryRecord to define a country in the snapshot.
+// Only the names from Google are included, the metadata and CLDR names are seeded by Use.
+type CountryRecord struct {
+	ID    string                 `json:"id"`
+	Names map[string]CountryName `json:"names"`
+}
+
+var (
+	cityCSVHeader    = []string{"placeid", "country_id", "lat", "lng", "timezone", "name_type", "lang", "name", "long_name", "address"}
+	countryCSVHeader = []string{"id", "lang", "name", "short_name"}
+)
+
+// sortedLangs to get the languages of the names in order.
+func sortedLangs(names map[string]struct{}) []string {
+	langs := make([]string, 0, len(names))
+	for one := range names {
+		langs = append(langs, one)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// formatFloat to format the optional float for CSV.
+func formatFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+// parseFloat to parse the optional float from CSV.
+func parseFloat(s string) (*float64, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// snapshotWriter to write the records in the format.
+type snapshotWriter struct {
+	format  Format
+	encoder *json.Encoder
+	csv     *csv.Writer
+	header  []string
+}
+
+// newSnapshotWriter to create the writer, header is used by FormatCSV.
+func newSnapshotWriter(w io.Writer, format Format, header []string) (*snapshotWriter, error) {
+	switch format {
+	case FormatNDJSON:
+		return &snapshotWriter{format: format, encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &snapshotWriter{format: format, csv: csv.NewWriter(w), header: header}, nil
+	}
+	return nil, ErrFormat
+}
+
+// writeRows to write the CSV rows, the header is written before the first rows.
+func (sw *snapshotWriter) writeRows(rows [][]string) error {
+	if sw.header != nil {
+		if err := sw.csv.Write(sw.header); err != nil {
+			return err
+		}
+		sw.header = nil
+	}
+	return sw.csv.WriteAll(rows)
+}
+
+func (sw *snapshotWriter) writeCity(city CityRecord) error {
+	if sw.format == FormatNDJSON {
+		return sw.encoder.Encode(city)
+	}
+
+	langs := make(map[string]struct{})
+	for one := range city.Names {
+		langs[one] = struct{}{}
+	}
+
+	// a city without names is written as one row with an empty lang, so it is kept
+	sorted := sortedLangs(langs)
+	if len(sorted) == 0 {
+		sorted = []string{""}
+	}
+
+	var rows [][]string
+	for _, lang := range sorted {
+		name := city.Names[lang]
+		rows = append(rows, []string{city.PlaceID, city.CountryID, formatFloat(city.Lat), formatFloat(city.Lng),
+			city.Timezone, city.NameType, lang, name.Name, name.LongName, name.Address})
+	}
+	return sw.writeRows(rows)
+}
+
+func (sw *snapshotWriter) writeCountry(country CountryRecord) error {
+	if sw.format == FormatNDJSON {
+		return sw.encoder.Encode(country)
+	}
+
+	langs := make(map[string]struct{})
+	for one := range country.Names {
+		langs[one] = struct{}{}
+	}
+
+	var rows [][]string
+	for _, lang := range sortedLangs(langs) {
+		name := country.Names[lang]
+		rows = append(rows, []string{country.ID, lang, name.Name, name.ShortName})
+	}
+	return sw.writeRows(rows)
+}
+
+// flush to flush the buffered CSV, the header is written if there is no record.
+func (sw *snapshotWriter) flush() error {
+	if sw.format != FormatCSV {
+		return nil
+	}
+
+	if sw.header != nil {
+		if err := sw.writeRows(nil); err != nil {
+			return err
+		}
+	}
+	sw.csv.Flush()
+	return sw.csv.Error()
+}
+
+// readCSV to read the CSV rows after checking the header.
+// The consecutive rows with the same key, the first field, are passed together.
+func readCSV(r io.Reader, header []string, fn func(rows [][]string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(header)
+
+	first, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for i, one := range header {
+		if first[i] != one {
+			return ErrFormat
+		}
+	}
+
+	var group [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if len(group) > 0 && group[0][0] != row[0] {
+			if err := fn(group); err != nil {
+				return err
+			}
+			group = nil
+		}
+		group = append(group, row)
+	}
+
+	if len(group) > 0 {
+		return fn(group)
+	}
+	return nil
+}
+
+// readNDJSON to read the JSON records line by line.
+func readNDJSON(r io.Reader, newRecord func() interface{}, fn func(record interface{}) error) error {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		record := newRecord()
+		if err := decoder.Decode(record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// readCities to read the city records in the format.
+func readCities(r io.Reader, format Format, fn func(CityRecord) error) error {
+	switch format {
+	case FormatNDJSON:
+		return readNDJSON(r, func() interface{} { return new(CityRecord) }, func(record interface{}) error {
+			return fn(*record.(*CityRecord))
+		})
+	case FormatCSV:
+		return readCSV(r, cityCSVHeader, func(rows [][]string) error {
+			city := CityRecord{PlaceID: rows[0][0], CountryID: rows[0][1], Timezone: rows[0][4], NameType: rows[0][5], Names: make(map[string]CityName)}
+
+			var err error
+			if city.Lat, err = parseFloat(rows[0][2]); err != nil {
+				return err
+			}
+			if city.Lng, err = parseFloat(rows[0][3]); err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				if len(row[6]) > 0 {
+					city.Names[row[6]] = CityName{Name: row[7], LongName: row[8], Address: row[9]}
+				}
+			}
+			return fn(city)
+		})
+	}
+	return ErrFormat
+}
+
+// readCountries to read the country records in the format.
+func readCountries(r io.Reader, format Format, fn func(CountryRecord) error) error {
+	switch format {
+	case FormatNDJSON:
+		return readNDJSON(r, func() interface{} { return new(CountryRecord) }, func(record interface{}) error {
+			return fn(*record.(*CountryRecord))
+		})
+	case FormatCSV:
+		return readCSV(r, countryCSVHeader, func(rows [][]string) error {
+			country := CountryRecord{ID: rows[0][0], Names: make(map[string]CountryName)}
+			for _, row := range rows {
+				country.Names[row[1]] = CountryName{Name: row[2], ShortName: row[3]}
+			}
+			return fn(country)
+		})
+	}
+	return ErrFormat
+}
+
+// ExportCities to write all the recorded cities in every language.
+func ExportCities(w io.Writer, format Format) error {
+	sw, err := newSnapshotWriter(w, format, cityCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	if err = exportCities(sw.writeCity); err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+// ExportCountries to write all the country names from Google in every language.
+func ExportCountries(w io.Writer, format Format) error {
+	sw, err := newSnapshotWriter(w, format, countryCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	if err = exportCountries(sw.writeCountry); err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+// ImportCities to import the cities of a snapshot, it can be done repeatedly.
+// The names of the languages not in use are skipped, the recorded names are kept if the imported ones are empty.
+// Return the count of the imported cities, error.
+func ImportCities(r io.Reader, format Format) (int, error) {
+	return importCities(func(fn func(CityRecord) error) error {
+		return readCities(r, format, fn)
+	})
+}
+
+// ImportCountries to import the countries of a snapshot, it can be done repeatedly.
+// The names of the languages not in use are skipped, the recorded names are kept if the imported ones are empty.
+// Return the count of the imported countries, error.
+func ImportCountries(r io.Reader, format Format) (int, error) {
+	return importCountries(func(fn func(CountryRecord) error) error {
+		return readCountries(r, format, fn)
+	})
+}
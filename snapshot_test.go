@@ -0,0 +1,98 @@
+package kkcity
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCityRecords() []CityRecord {
+	lat, lng := 24.4798, 118.0894
+	return []CityRecord{
+		{
+			PlaceID:   "placeid1",
+			CountryID: "CN",
+			Lat:       &lat,
+			Lng:       &lng,
+			Timezone:  "Asia/Shanghai",
+			NameType:  "locality",
+			Names: map[string]CityName{
+				"en": {Name: "Xiamen", LongName: "Xiamen City", Address: "Xiamen, Fujian, China"},
+				"zh": {Name: "厦门市", Address: "中国福建省厦门市"},
+			},
+		},
+		{
+			PlaceID:   "placeid2",
+			CountryID: "GB",
+			Names:     map[string]CityName{"en": {Name: "Bath, \"Somerset\""}},
+		},
+		{
+			PlaceID:   "placeid3",
+			CountryID: "FR",
+			Names:     map[string]CityName{},
+		},
+	}
+}
+
+func TestCitySnapshotFormats(t *testing.T) {
+	for _, format := range []Format{FormatNDJSON, FormatCSV} {
+		var buf bytes.Buffer
+		sw, err := newSnapshotWriter(&buf, format, cityCSVHeader)
+		assert.NoError(t, err, "Should be able to create writer.")
+
+		for _, one := range testCityRecords() {
+			assert.NoError(t, sw.writeCity(one), "Should be able to write city.")
+		}
+		assert.NoError(t, sw.flush(), "Should be able to flush.")
+
+		var cities []CityRecord
+		err = readCities(&buf, format, func(city CityRecord) error {
+			cities = append(cities, city)
+			return nil
+		})
+		assert.NoError(t, err, "Should be able to read cities.")
+		assert.Equal(t, testCityRecords(), cities, "Cities are wrong.")
+	}
+}
+
+func TestCountrySnapshotFormats(t *testing.T) {
+	countries := []CountryRecord{
+		{ID: "CN", Names: map[string]CountryName{"en": {Name: "China", ShortName: "CN"}, "zh": {Name: "中国"}}},
+		{ID: "US", Names: map[string]CountryName{"en": {Name: "United States"}}},
+	}
+
+	for _, format := range []Format{FormatNDJSON, FormatCSV} {
+		var buf bytes.Buffer
+		sw, err := newSnapshotWriter(&buf, format, countryCSVHeader)
+		assert.NoError(t, err, "Should be able to create writer.")
+
+		for _, one := range countries {
+			assert.NoError(t, sw.writeCountry(one), "Should be able to write country.")
+		}
+		assert.NoError(t, sw.flush(), "Should be able to flush.")
+
+		var result []CountryRecord
+		err = readCountries(&buf, format, func(country CountryRecord) error {
+			result = append(result, country)
+			return nil
+		})
+		assert.NoError(t, err, "Should be able to read countries.")
+		assert.Equal(t, countries, result, "Countries are wrong.")
+	}
+}
+
+func TestSnapshotFormatError(t *testing.T) {
+	_, err := newSnapshotWriter(&bytes.Buffer{}, Format(-1), nil)
+	assert.Equal(t, ErrFormat, err, "Format should be wrong.")
+
+	err = readCountries(strings.NewReader("id,name,lang,short_name\n"), FormatCSV, func(CountryRecord) error { return nil })
+	assert.Equal(t, ErrFormat, err, "Header should be wrong.")
+
+	// an empty CSV only has the header
+	var buf bytes.Buffer
+	sw, _ := newSnapshotWriter(&buf, FormatCSV, countryCSVHeader)
+	assert.NoError(t, sw.flush(), "Should be able to flush.")
+	assert.Equal(t, "id,lang,name,short_name\n", buf.String(), "Header is wrong.")
+}
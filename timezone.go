@@ -0,0 +1,54 @@
+package kkcity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bradfitz/latlong"
+)
+
+// ErrNoTimezone to define the timezone of a location can't be resolved.
+var ErrNoTimezone = errors.New("No timezone found.")
+
+// TimezoneProvider to resolve the IANA timezone of a location, such as Asia/Shanghai.
+type TimezoneProvider interface {
+	Timezone(lat, lng float64) (string, error)
+}
+
+// OfflineTimezone to resolve timezone with the embedded timezone boundary dataset.
+type OfflineTimezone struct{}
+
+// Timezone to get the timezone of a location.
+func (OfflineTimezone) Timezone(lat, lng float64) (string, error) {
+	if zone := latlong.LookupZoneName(lat, lng); len(zone) > 0 {
+		return zone, nil
+	}
+	return "", ErrNoTimezone
+}
+
+// GoogleTimezone to resolve timezone with Google Time Zone API.
+type GoogleTimezone struct{}
+
+// Timezone to get the timezone of a location.
+func (GoogleTimezone) Timezone(lat, lng float64) (string, error) {
+	return requestTimezone(context.Background(), lat, lng)
+}
+
+// timezoneProvider used to resolve the timezone of cities.
+var timezoneProvider TimezoneProvider = OfflineTimezone{}
+
+// SetTimezoneProvider to set how the timezone of cities is resolved.
+// The default is OfflineTimezone.
+func SetTimezoneProvider(provider TimezoneProvider) {
+	timezoneProvider = provider
+}
+
+// getLocalTime to get the current time of the timezone.
+func getLocalTime(zone string) (time.Time, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().In(loc), nil
+}
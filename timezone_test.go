@@ -0,0 +1,26 @@
+package kkcity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineTimezone(t *testing.T) {
+	zone, err := OfflineTimezone{}.Timezone(24.47, 118.08)
+	assert.NoError(t, err, "Should be able to get timezone.")
+	assert.Equal(t, "Asia/Shanghai", zone, "Timezone is wrong.")
+
+	zone, err = OfflineTimezone{}.Timezone(51.50, -0.12)
+	assert.NoError(t, err, "Should be able to get timezone.")
+	assert.Equal(t, "Europe/London", zone, "Timezone is wrong.")
+}
+
+func TestGetLocalTime(t *testing.T) {
+	now, err := getLocalTime("Asia/Shanghai")
+	assert.NoError(t, err, "Should be able to get local time.")
+	assert.Equal(t, "Asia/Shanghai", now.Location().String(), "Location is wrong.")
+
+	_, err = getLocalTime("Nowhere/City")
+	assert.Error(t, err, "Timezone should be wrong.")
+}